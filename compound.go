@@ -0,0 +1,62 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import "strings"
+
+// splitCompounds returns raw events with raw events of compound cells replaced by raw events of their parts.
+// Compound cell holds two events of different types, e.g. lecture and lab, with own teachers and locations
+// and shared title and dates: "Физика. Иванов И.И. лекции. 101. Петров П.П. лабораторные занятия. 202. [05.09-05.12 к.н.]"
+// is split into "Физика. Иванов И.И. лекции. 101. [05.09-05.12 к.н.]"
+// and "Физика. Петров П.П. лабораторные занятия. 202. [05.09-05.12 к.н.]".
+// Compound cells are split only with WithCompoundCells.
+func splitCompounds(rawEvents []RawEvent) []RawEvent {
+	split := make([]RawEvent, 0, len(rawEvents))
+	for _, raw := range rawEvents {
+		split = append(split, splitCompound(raw)...)
+	}
+	return split
+}
+
+// splitCompound returns raw events of parts of compound cell, or raw event itself if its cell isn't compound.
+// Cell is compound if it has exactly two type keywords of different types before dates
+// and both of them are preceded by teachers, the second one following location of the first part.
+func splitCompound(raw RawEvent) []RawEvent {
+	datesIndexes := datesRegexp.FindAllStringIndex(raw.data, -1)
+	if datesIndexes == nil {
+		return []RawEvent{raw}
+	}
+	head, dates := raw.data[:datesIndexes[len(datesIndexes)-1][0]], raw.data[datesIndexes[len(datesIndexes)-1][0]:]
+	typeIndexes := typeRegexp(eventTypes).FindAllStringIndex(head, -1)
+	if len(typeIndexes) != 2 {
+		return []RawEvent{raw}
+	}
+	first, second := typeIndexes[0], typeIndexes[1]
+	if eventTypes[strings.ToLower(head[first[0]:first[1]-1])] == eventTypes[strings.ToLower(head[second[0]:second[1]-1])] {
+		return []RawEvent{raw}
+	}
+
+	// Title and teacher of the first part precede its type, and title may have several segments.
+	beforeType := strings.TrimSpace(head[:first[0]])
+	teacherIndexes := trailingTeacherRegexp.FindStringIndex(beforeType)
+	if teacherIndexes == nil || teacherIndexes[0] == 0 {
+		return []RawEvent{raw}
+	}
+	title := beforeType[:teacherIndexes[0]]
+	// Location of the first part and teacher of the second part are between types.
+	between := strings.TrimSpace(head[first[1]:second[0]])
+	indexes := trailingTeacherRegexp.FindStringSubmatchIndex(between)
+	if indexes == nil {
+		return []RawEvent{raw}
+	}
+	location, teacher := between[:indexes[0]], strings.TrimSuffix(between[indexes[2]:indexes[3]], ".")+"."
+
+	firstPart, secondPart := raw, raw
+	firstPart.data = head[:first[1]] + " "
+	if location != "" {
+		firstPart.data += location + ". "
+	}
+	firstPart.data += dates
+	secondPart.data = title + ". " + teacher + " " + head[second[0]:] + dates
+	return []RawEvent{firstPart, secondPart}
+}
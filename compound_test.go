@@ -0,0 +1,89 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+func Test_splitCompound(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{
+			"LectureAndLab",
+			"Физика. Иванов И.И. лекции. 101. Петров П.П. лабораторные занятия. (1 подгруппа). 202. [05.09-05.12 к.н.]",
+			[]string{"Физика. Иванов И.И. лекции. 101. [05.09-05.12 к.н.]", "Физика. Петров П.П. лабораторные занятия. (1 подгруппа). 202. [05.09-05.12 к.н.]"},
+		},
+		{
+			"WithoutFirstLocation",
+			"Физика. Иванов И.И. лекции. Петров П. П. лабораторные занятия. 202. [05.09] (note)",
+			[]string{"Физика. Иванов И.И. лекции. [05.09] (note)", "Физика. Петров П. П. лабораторные занятия. 202. [05.09] (note)"},
+		},
+		{
+			"MultiSegmentTitle",
+			"Физика. Механика. Иванов И.И. лекции. 101. Петров П.П. лабораторные занятия. 202. [05.09]",
+			[]string{"Физика. Механика. Иванов И.И. лекции. 101. [05.09]", "Физика. Механика. Петров П.П. лабораторные занятия. 202. [05.09]"},
+		},
+		{"SingleType", "Физика. Иванов И.И. лекции. 101. [05.09]", []string{"Физика. Иванов И.И. лекции. 101. [05.09]"}},
+		{"SameTypes", "Физика. Иванов И.И. лекции. 101. Петров П.П. лекции. 202. [05.09]", []string{"Физика. Иванов И.И. лекции. 101. Петров П.П. лекции. 202. [05.09]"}},
+		{"WithoutTitle", "Иванов И.И. лекции. 101. Петров П.П. лабораторные занятия. 202. [05.09]", []string{"Иванов И.И. лекции. 101. Петров П.П. лабораторные занятия. 202. [05.09]"}},
+		{"WithoutSecondTeacher", "Физика. Иванов И.И. лекции. 101. лабораторные занятия. 202. [05.09]", []string{"Физика. Иванов И.И. лекции. 101. лабораторные занятия. 202. [05.09]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitCompound(RawEvent{data: tt.data})
+			if len(got) != len(tt.want) {
+				t.Fatalf("len(splitCompound()) = %d, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].data != tt.want[i] {
+					t.Errorf("splitCompound()[%d].data = %q, want %q", i, got[i].data, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParser_parseText_compound(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, r := range "Физика. Иванов И.И. лекции. 101. Петров П.П. лабораторные занятия. (1 подгруппа). 202. [05.09]" {
+		texts = append(texts, pdf.Text{X: 46, Y: 500, S: string(r)})
+	}
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	schedule, err := NewParser().parseText(&reader.Content{Texts: texts}, initialDate, "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	if len(schedule.Events) != 1 {
+		t.Errorf("len(Schedule.Events) = %d without WithCompoundCells, want %d", len(schedule.Events), 1)
+	}
+
+	schedule, err = NewParser(WithCompoundCells()).parseText(&reader.Content{Texts: texts}, initialDate, "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	want := []Event{
+		{Title: "Физика", Teacher: "Иванов И.И.", Type: "lecture", Location: "101", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "Физика", Teacher: "Петров П.П.", Type: "lab", Subgroup: "1 подгруппа", SubgroupNumber: 1, Location: "202", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 12, 0, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+	if len(schedule.Events) != len(want) {
+		t.Fatalf("len(Schedule.Events) = %d, want %d", len(schedule.Events), len(want))
+	}
+	for i := range want {
+		if !schedule.Events[i].Equal(want[i]) {
+			t.Errorf("events[%d] = %+v, want %+v", i, schedule.Events[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import "strings"
+
+// Penalties subtracted from confidence of event for guesses made by parsing.
+const (
+	ambiguousSplitPenalty  = 0.4 // teacher has segments that are not initials, see isAmbiguousSplit
+	trailingTeacherPenalty = 0.2 // teacher follows location instead of preceding type
+	missingTeacherPenalty  = 0.2 // no teacher, so it may be parsed as part of title or location
+	missingLocationPenalty = 0.2 // no location, so it may be parsed as part of subgroup or note
+)
+
+// scoreEvent returns confidence from 0 to 1 of event parsed from raw event.
+// Event of cell that is parsed without guesses has confidence 1, and each guess lowers it by its penalty.
+// Cancelled event has no fields to tell apart, so its confidence is 1.
+func scoreEvent(raw *RawEvent, event *Event) float64 {
+	if event.Cancelled || event.Dateless {
+		return 1
+	}
+	confidence := 1.0
+	if event.Teacher == "" {
+		confidence -= missingTeacherPenalty
+	} else {
+		if isAmbiguousSplit(event.Teacher) {
+			confidence -= ambiguousSplitPenalty
+		}
+		typeIndexes := typeRegexp(eventTypes).FindStringIndex(raw.data)
+		if typeIndexes != nil && strings.LastIndex(raw.data, strings.TrimSuffix(event.Teacher, ".")) > typeIndexes[0] {
+			confidence -= trailingTeacherPenalty
+		}
+	}
+	if event.Location == "" {
+		confidence -= missingLocationPenalty
+	}
+	if confidence < 0 {
+		return 0
+	}
+	return confidence
+}
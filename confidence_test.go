@@ -0,0 +1,60 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestWithMinConfidence(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+	rawEvents := []RawEvent{
+		{data: "Clean. Teacher T.T. лекции. Location. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+		{data: "Trailing. лекции. Location. Teacher T.T. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+		{data: "Ambiguous. Subtitle. Teacher T.T. лекции. Location. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+		{data: "Bare. лекции. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+	}
+
+	t.Run("Scores", func(t *testing.T) {
+		events, err := NewParser(WithMinConfidence(0.1)).parseEvents(rawEvents)
+		if err != nil {
+			t.Fatalf("Parser.parseEvents() error = %v", err)
+		}
+		for i, want := range []float64{1, 0.8, 0.6, 0.6} {
+			if math.Abs(events[i].Confidence-want) > 1e-9 {
+				t.Errorf("events[%d].Confidence = %v, want %v", i, events[i].Confidence, want)
+			}
+		}
+	})
+
+	t.Run("Threshold", func(t *testing.T) {
+		events, err := NewParser(WithMinConfidence(0.7)).parseEvents(rawEvents)
+		if err != nil {
+			t.Fatalf("Parser.parseEvents() error = %v", err)
+		}
+		if len(events) != 2 || events[0].Title != "Clean" || events[1].Title != "Trailing" {
+			t.Errorf("Parser.parseEvents() = %v, want events Clean and Trailing", events)
+		}
+	})
+
+	t.Run("Handler", func(t *testing.T) {
+		var indexes []int
+		handler := func(index int, raw RawEvent, err error) {
+			if !errors.Is(err, ErrLowConfidence) {
+				t.Errorf("handler error = %v, want %v", err, ErrLowConfidence)
+			}
+			indexes = append(indexes, index)
+		}
+		if _, err := NewParser(WithMinConfidence(0.7), WithErrorHandler(handler)).parseEvents(rawEvents); err != nil {
+			t.Fatalf("Parser.parseEvents() error = %v", err)
+		}
+		if len(indexes) != 2 || indexes[0] != 2 || indexes[1] != 3 {
+			t.Errorf("handler indexes = %v, want %v", indexes, []int{2, 3})
+		}
+	})
+}
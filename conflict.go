@@ -0,0 +1,103 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"sort"
+	"time"
+)
+
+// ConflictKind is category of conflict of overlapping occurrences of events.
+type ConflictKind string
+
+const (
+	ConflictRoom     ConflictKind = "room"     // different classes take place in the same room
+	ConflictStudents ConflictKind = "students" // the same students have several classes
+)
+
+// Conflict is overlap of occurrences of two events.
+type Conflict struct {
+	Kind ConflictKind `json:"kind"`
+	// Interval is time span both occurrences take place in.
+	Interval Interval `json:"interval"`
+	// Events are clones of events with single date of occurrence, in chronological order of occurrences.
+	Events []Event `json:"events"`
+	// Subgroups and Locations are subgroups and locations of Events, empty for whole group or unknown location.
+	Subgroups []string `json:"subgroups"`
+	Locations []string `json:"locations"`
+}
+
+// ConflictDay is conflicts of occurrences on civil date.
+type ConflictDay struct {
+	Date      time.Time  `json:"date"` // civil date at midnight
+	Conflicts []Conflict `json:"conflicts"`
+}
+
+// ConflictReport returns conflicts of overlapping occurrences of events grouped by civil date in chronological order,
+// e.g. for advisor reviewing draft schedule. Double-booked room, i.e. the same location of different classes,
+// is ConflictRoom, and occurrences of the same group with the same subgroup or for whole group are ConflictStudents,
+// so that parallel classes of different subgroups don't conflict. Classes with equal title, teacher, type and location
+// are taken as one class of several groups, and online locations aren't rooms. Cancelled events are skipped.
+func ConflictReport(events []Event) []ConflictDay {
+	occurrences := make([]eventOccurrence, 0)
+	for _, o := range eventOccurrences(events) {
+		if !o.event.Cancelled {
+			occurrences = append(occurrences, o)
+		}
+	}
+	byDate := make(map[int]*ConflictDay)
+	for i, a := range occurrences {
+		for _, b := range occurrences[i+1:] {
+			if !b.Start.Before(a.End) {
+				break
+			}
+			if a.event == b.event {
+				continue
+			}
+			for _, kind := range conflictKinds(a.event, b.event) {
+				day, ok := byDate[days(a.Start)]
+				if !ok {
+					day = &ConflictDay{Date: a.Date}
+					byDate[days(a.Start)] = day
+				}
+				day.Conflicts = append(day.Conflicts, newConflict(kind, a, b))
+			}
+		}
+	}
+	report := make([]ConflictDay, 0, len(byDate))
+	for _, day := range byDate {
+		report = append(report, *day)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Date.Before(report[j].Date) })
+	return report
+}
+
+// conflictKinds returns kinds of conflict of overlapping occurrences of events a and b.
+func conflictKinds(a, b *Event) []ConflictKind {
+	kinds := make([]ConflictKind, 0, 2)
+	sameClass := a.Title == b.Title && a.Teacher == b.Teacher && a.Type == b.Type
+	sameRoom := a.Location != "" && synonymKey(a.Location) == synonymKey(b.Location) && !a.IsOnline()
+	if sameRoom && !sameClass {
+		kinds = append(kinds, ConflictRoom)
+	}
+	if a.Group == b.Group && (a.Subgroup == "" || b.Subgroup == "" || a.Common || b.Common || a.Subgroup == b.Subgroup) && !(sameClass && sameRoom) {
+		kinds = append(kinds, ConflictStudents)
+	}
+	return kinds
+}
+
+// newConflict returns Conflict of kind of overlapping occurrences a and b, a starting not later than b.
+func newConflict(kind ConflictKind, a, b eventOccurrence) Conflict {
+	conflict := Conflict{Kind: kind, Interval: Interval{b.Start, a.End}}
+	if b.End.Before(a.End) {
+		conflict.Interval.End = b.End
+	}
+	for _, o := range []eventOccurrence{a, b} {
+		event := o.event.Clone()
+		event.Dates = []EventDate{{Start: o.Start, End: o.End, Frequency: FrequencyOnce}}
+		conflict.Events = append(conflict.Events, event)
+		conflict.Subgroups = append(conflict.Subgroups, o.event.Subgroup)
+		conflict.Locations = append(conflict.Locations, o.event.Location)
+	}
+	return conflict
+}
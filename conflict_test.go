@@ -0,0 +1,116 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConflictReport(t *testing.T) {
+	once := func(day, hour int) []EventDate {
+		return []EventDate{{Start: time.Date(2000, 9, day, hour, 30, 0, 0, loc), End: time.Date(2000, 9, day, hour+1, 10, 0, 0, loc), Frequency: FrequencyOnce}}
+	}
+
+	tests := []struct {
+		name   string
+		events []Event
+		want   []ConflictKind
+	}{
+		{
+			"NoOverlap",
+			[]Event{
+				{Title: "Физика", Teacher: "Иванов И.И.", Location: "101", Group: "ИВТ-101", Dates: once(5, 8)},
+				{Title: "Химия", Teacher: "Петров П.П.", Location: "101", Group: "ИВТ-101", Dates: once(5, 9)},
+			},
+			nil,
+		},
+		{
+			"RoomDoubleBooking",
+			[]Event{
+				{Title: "Физика", Teacher: "Иванов И.И.", Location: "101", Group: "ИВТ-101", Dates: once(5, 8)},
+				{Title: "Химия", Teacher: "Петров П.П.", Location: " 101", Group: "ИВТ-102", Dates: once(5, 8)},
+			},
+			[]ConflictKind{ConflictRoom},
+		},
+		{
+			"SubgroupClash",
+			[]Event{
+				{Title: "Физика", Location: "101", Subgroup: "1 подгруппа", Dates: once(5, 8)},
+				{Title: "Химия", Location: "202", Dates: once(5, 8)},
+			},
+			[]ConflictKind{ConflictStudents},
+		},
+		{
+			"ParallelSubgroups",
+			[]Event{
+				{Title: "Физика", Location: "101", Subgroup: "1 подгруппа", Dates: once(5, 8)},
+				{Title: "Химия", Location: "202", Subgroup: "2 подгруппа", Dates: once(5, 8)},
+			},
+			nil,
+		},
+		{
+			"StreamLecture",
+			[]Event{
+				{Title: "Физика", Teacher: "Иванов И.И.", Type: "lecture", Location: "101", Group: "ИВТ-101", Dates: once(5, 8)},
+				{Title: "Физика", Teacher: "Иванов И.И.", Type: "lecture", Location: "101", Group: "ИВТ-102", Dates: once(5, 8)},
+			},
+			nil,
+		},
+		{
+			"RoomAndStudents",
+			[]Event{
+				{Title: "Физика", Location: "101", Dates: once(5, 8)},
+				{Title: "Химия", Location: "101", Dates: once(5, 8)},
+				{Title: "Cancelled", Location: "101", Cancelled: true, Dates: once(5, 8)},
+			},
+			[]ConflictKind{ConflictRoom, ConflictStudents},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ConflictReport(tt.events)
+			var kinds []ConflictKind
+			for _, day := range report {
+				for _, conflict := range day.Conflicts {
+					kinds = append(kinds, conflict.Kind)
+				}
+			}
+			if !reflect.DeepEqual(kinds, tt.want) {
+				t.Errorf("ConflictReport() kinds = %v, want %v", kinds, tt.want)
+			}
+		})
+	}
+}
+
+func TestConflictReport_details(t *testing.T) {
+	events := []Event{
+		{Title: "Физика", Location: "101", Subgroup: "1 подгруппа", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 12, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+		{Title: "Химия", Location: "202", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 12, 9, 0, 0, 0, loc), End: time.Date(2000, 9, 12, 12, 0, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+	report := ConflictReport(events)
+	if len(report) != 1 || len(report[0].Conflicts) != 1 {
+		t.Fatalf("ConflictReport() = %+v, want single conflict", report)
+	}
+	if want := time.Date(2000, 9, 12, 0, 0, 0, 0, loc); !report[0].Date.Equal(want) {
+		t.Errorf("ConflictDay.Date = %v, want %v", report[0].Date, want)
+	}
+	conflict := report[0].Conflicts[0]
+	if want := (Interval{time.Date(2000, 9, 12, 9, 0, 0, 0, loc), time.Date(2000, 9, 12, 10, 10, 0, 0, loc)}); conflict.Interval != want {
+		t.Errorf("Conflict.Interval = %v, want %v", conflict.Interval, want)
+	}
+	if want := []string{"1 подгруппа", ""}; !reflect.DeepEqual(conflict.Subgroups, want) {
+		t.Errorf("Conflict.Subgroups = %q, want %q", conflict.Subgroups, want)
+	}
+	if want := []string{"101", "202"}; !reflect.DeepEqual(conflict.Locations, want) {
+		t.Errorf("Conflict.Locations = %q, want %q", conflict.Locations, want)
+	}
+	if got := conflict.Events[0].Dates; len(got) != 1 || !got[0].Start.Equal(time.Date(2000, 9, 12, 8, 30, 0, 0, loc)) {
+		t.Errorf("Conflict.Events[0].Dates = %v, want single occurrence on 2000-09-12", got)
+	}
+}
@@ -3,19 +3,151 @@
 package scheduleparser
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
+// Frequency is a recurrence frequency of schedule event date.
+type Frequency string
+
+const (
+	FrequencyOnce       Frequency = "once"       // single date
+	FrequencyEvery      Frequency = "every"      // every week (к.н.)
+	FrequencyThroughout Frequency = "throughout" // every other week (ч.н.)
+	FrequencyContinuous Frequency = "continuous" // every day of range (practice blocks)
+)
+
+// Parity is parity of weeks of schedule event date, e.g. "(чётная)" in dates of event.
+type Parity string
+
+const (
+	ParityEven Parity = "even" // even weeks (чётная)
+	ParityOdd  Parity = "odd"  // odd weeks (нечётная)
+)
+
 // EventDate contains start/end datetime and frequency of schedule event.
 type EventDate struct {
 	Start     time.Time `json:"start"`
 	End       time.Time `json:"end"`
-	Frequency string    `json:"frequency"`
+	Frequency Frequency `json:"frequency"`
+
+	// OpenEnded reports whether event date has start time only.
+	// End of open-ended date is equal to its start.
+	OpenEnded bool `json:"openEnded,omitempty"`
+
+	// OpenEndedRange reports whether date range has start date only, e.g. "с 14.09 до конца семестра".
+	// End of such range is the last date of semester from header.
+	OpenEndedRange bool `json:"openEndedRange,omitempty"`
+
+	// PairNumber is 1-based number of time slot starting at start time of event date, e.g. 3 for "пара №3".
+	// It is set only with WithTimeSlots option, and it is zero if start time doesn't match any slot.
+	PairNumber int `json:"pairNumber,omitempty"`
+
+	// Exceptions are start datetimes of occurrences excluded from recurrence, e.g. "кроме 12.10".
+	Exceptions []time.Time `json:"exceptions,omitempty"`
+
+	// Parity is parity of weeks written for group of dates, e.g. "[14.09, 28.09 (чётная); 21.09 (нечётная)]".
+	// It is empty if dates have no parity.
+	Parity Parity `json:"parity,omitempty"`
+}
+
+// Clone returns copy of event date that shares no slices with it.
+func (eventDate EventDate) Clone() EventDate {
+	if eventDate.Exceptions != nil {
+		eventDate.Exceptions = append([]time.Time(nil), eventDate.Exceptions...)
+	}
+	return eventDate
+}
+
+// eventDateJSON is json shape of EventDate with separate date and times.
+// Dates and times are civil ones of UTC+3, the time zone of schedules, regardless of location of EventDate.
+// Until is the last date of date range and is omitted if it is the same as date.
+type eventDateJSON struct {
+	Date           string    `json:"date"`
+	Start          string    `json:"start"`
+	End            string    `json:"end"`
+	Week           int       `json:"week"` // ISO week number of date
+	Frequency      Frequency `json:"frequency"`
+	Until          string    `json:"until,omitempty"`
+	OpenEnded      bool      `json:"openEnded,omitempty"`
+	OpenEndedRange bool      `json:"openEndedRange,omitempty"`
+	PairNumber     int       `json:"pairNumber,omitempty"`
+	Exceptions     []string  `json:"exceptions,omitempty"`
+	Parity         Parity    `json:"parity,omitempty"`
+}
+
+const (
+	jsonDateLayout = "2006-01-02"
+	jsonTimeLayout = "15:04"
+)
+
+// MarshalJSON encodes EventDate as object with "YYYY-MM-DD" date, "HH:MM" start and end times,
+// ISO week number of date as week and frequency. Datetimes are converted to UTC+3 before encoding.
+// Last date of date range is encoded as until, and exceptions are encoded as "YYYY-MM-DD" dates.
+func (eventDate EventDate) MarshalJSON() ([]byte, error) {
+	start, end := eventDate.Start.In(loc), eventDate.End.In(loc)
+	_, week := start.ISOWeek()
+	var until string
+	if date, endDate := start.Format(jsonDateLayout), end.Format(jsonDateLayout); endDate != date {
+		until = endDate
+	}
+	var exceptions []string
+	for _, exception := range eventDate.Exceptions {
+		exceptions = append(exceptions, exception.In(loc).Format(jsonDateLayout))
+	}
+	return json.Marshal(eventDateJSON{
+		start.Format(jsonDateLayout),
+		start.Format(jsonTimeLayout),
+		end.Format(jsonTimeLayout),
+		week,
+		eventDate.Frequency,
+		until,
+		eventDate.OpenEnded,
+		eventDate.OpenEndedRange,
+		eventDate.PairNumber,
+		exceptions,
+		eventDate.Parity,
+	})
+}
+
+// UnmarshalJSON decodes EventDate from object produced by MarshalJSON.
+// Datetimes are decoded in UTC+3. Week is ignored because it is derived from date.
+func (eventDate *EventDate) UnmarshalJSON(data []byte) error {
+	var v eventDateJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Until == "" {
+		v.Until = v.Date
+	}
+	layout := jsonDateLayout + " " + jsonTimeLayout
+	start, err := time.ParseInLocation(layout, v.Date+" "+v.Start, loc)
+	if err != nil {
+		return fmt.Errorf("incorrect start: %w", err)
+	}
+	end, err := time.ParseInLocation(layout, v.Until+" "+v.End, loc)
+	if err != nil {
+		return fmt.Errorf("incorrect end: %w", err)
+	}
+	var exceptions []time.Time
+	for _, date := range v.Exceptions {
+		exception, err := time.ParseInLocation(layout, date+" "+v.Start, loc)
+		if err != nil {
+			return fmt.Errorf("incorrect exception: %w", err)
+		}
+		exceptions = append(exceptions, exception)
+	}
+	*eventDate = EventDate{Start: start, End: end, Frequency: v.Frequency, OpenEnded: v.OpenEnded, OpenEndedRange: v.OpenEndedRange, PairNumber: v.PairNumber, Exceptions: exceptions, Parity: v.Parity}
+	return nil
 }
 
+// legacyEventDate is EventDate encoded in legacy flat shape with start/end datetimes.
+type legacyEventDate EventDate
+
 // normalize adds a year to start datetime and end datetime by given date.
 func (eventDate *EventDate) normalize(date time.Time) {
 	year, month, day := date.Date()
@@ -33,50 +165,425 @@ func (eventDate *EventDate) normalize(date time.Time) {
 
 const dateFormat = "02.01"
 
+// isDate reports whether s is date in dateFormat.
+func isDate(s string) bool {
+	_, err := time.Parse(dateFormat, s)
+	return err == nil
+}
+
+// academicYearStart returns initial date of academic year starting in given year.
+func academicYearStart(year int) time.Time {
+	return time.Date(year, time.August, 1, 0, 0, 0, 0, loc)
+}
+
 var loc = time.FixedZone("UTC+3", 3*60*60)
 
 // NewEventDate creates EventDate by start date and end date strings,
 // adds time to date by eventTime and returns *EventDate.
-func NewEventDate(start string, end string, eventTime *EventTime, frequency string) *EventDate {
+func NewEventDate(start string, end string, eventTime *EventTime, frequency Frequency) *EventDate {
 	dateStart, _ := time.ParseInLocation(dateFormat, start, loc)
 	dateEnd, _ := time.ParseInLocation(dateFormat, end, loc)
 	dateStart = dateStart.Add(time.Hour*time.Duration(eventTime.start.hour) + time.Minute*time.Duration(eventTime.start.min))
 	dateEnd = dateEnd.Add(time.Hour*time.Duration(eventTime.end.hour) + time.Minute*time.Duration(eventTime.end.min))
 
-	return &EventDate{dateStart, dateEnd, frequency}
+	return &EventDate{Start: dateStart, End: dateEnd, Frequency: frequency}
+}
+
+// dashReplacer replaces dash variants in date ranges with hyphen-minus.
+var dashReplacer = strings.NewReplacer(
+	"\u2010", "-", // hyphen
+	"\u2011", "-", // non-breaking hyphen
+	"\u2013", "-", // en dash
+	"\u2014", "-", // em dash
+	"\u2212", "-", // minus sign
+)
+
+// dateSpaceRegexp matches spaces around separator of digits, e.g. "14. 09" or "10: 15".
+var dateSpaceRegexp = regexp.MustCompile(`(\d)\s*([.:-])\s*(\d)`)
+
+// rangeDashRegexp matches dash of date range with optional surrounding spaces, e.g. "14.09 - 28.12".
+var rangeDashRegexp = regexp.MustCompile(`(\d{2}\.\d{2})[\s\x{00A0}]*-[\s\x{00A0}]*(\d{2}\.\d{2})`)
+
+// openEndedRegexp matches start time without end at the end of date,
+// e.g. "с 14:00" or "с 14:00 до конца дня".
+var openEndedRegexp = regexp.MustCompile(`\s*с\s+(\d{1,2}):(\d{2})(?:\s+до конца дня)?$`)
+
+// parseOpenEnded searches for start time without end in date string and cuts it off.
+// It returns *EventTime with equal start and end and true if start time is found.
+func parseOpenEnded(date *string) (*EventTime, bool, error) {
+	submatches := openEndedRegexp.FindStringSubmatch(*date)
+	if submatches == nil {
+		return nil, false, nil
+	}
+	hour, _ := strconv.Atoi(submatches[1])
+	min, _ := strconv.Atoi(submatches[2])
+	if hour > 23 || min > 59 {
+		return nil, false, fmt.Errorf("%w: incorrect start time %q", ErrDateParse, strings.TrimSpace(submatches[0]))
+	}
+	*date = strings.TrimSuffix(*date, submatches[0])
+	return &EventTime{Clock{hour, min}, Clock{hour, min}}, true, nil
+}
+
+// dateTimeRegexp matches time range at the end of date, e.g. "12.09 8-9:30".
+// Minutes are separated by colon only, so that date ranges aren't taken for time ranges.
+var dateTimeRegexp = regexp.MustCompile(`\s+(\d{1,2})(?::(\d{2}))?\s*-\s*(\d{1,2})(?::(\d{2}))?$`)
+
+// parseDateTime cuts time range from the end of date and returns *EventTime of it,
+// or nil if there is no time range. Times are parsed by layouts unless layouts are nil.
+func parseDateTime(date *string, layouts []string) (*EventTime, error) {
+	if layouts != nil {
+		submatches := layoutDateTimeRegexp.FindStringSubmatch(*date)
+		if submatches == nil {
+			return nil, nil
+		}
+		eventTime, err := newLayoutTimeRange(submatches[1], submatches[2], layouts)
+		if err != nil {
+			return nil, fmt.Errorf("incorrect time %q: %w", strings.TrimSpace(submatches[0]), err)
+		}
+		*date = strings.TrimSuffix(*date, submatches[0])
+		return eventTime, nil
+	}
+	submatches := dateTimeRegexp.FindStringSubmatch(*date)
+	if submatches == nil {
+		return nil, nil
+	}
+	eventTime, err := newTimeRange(submatches[1:])
+	if err != nil {
+		return nil, fmt.Errorf("incorrect time %q: %w", strings.TrimSpace(submatches[0]), err)
+	}
+	*date = strings.TrimSuffix(*date, submatches[0])
+	return eventTime, nil
+}
+
+// exceptionRegexp matches dates excluded from recurrence at the end of date, e.g. "кроме 12.10".
+// Several dates are separated by spaces or "и", e.g. "кроме 12.10 и 19.10".
+var exceptionRegexp = regexp.MustCompile(`(?:^|\s+)кроме\s+(.+)$`)
+
+// exceptionSeparatorRegexp matches separator of excluded dates.
+var exceptionSeparatorRegexp = regexp.MustCompile(`\s+(?:и\s+)?`)
+
+// parseExceptions cuts excluded dates from the end of date and returns them.
+func parseExceptions(date *string) ([]string, error) {
+	submatches := exceptionRegexp.FindStringSubmatch(*date)
+	if submatches == nil {
+		return nil, nil
+	}
+	exceptions := exceptionSeparatorRegexp.Split(submatches[1], -1)
+	for _, exception := range exceptions {
+		if !isDate(exception) {
+			return nil, fmt.Errorf("%w: incorrect excluded date %q", ErrDateParse, exception)
+		}
+	}
+	*date = strings.TrimSuffix(*date, submatches[0])
+	return exceptions, nil
+}
+
+// exclude adds excluded dates to exceptions of event date.
+// Years of excluded dates are inferred by given date and time is start time of event date.
+func (eventDate *EventDate) exclude(exceptions []string, date time.Time) error {
+	for _, exception := range exceptions {
+		t, _ := time.ParseInLocation(dateFormat, exception, loc)
+		excluded := EventDate{Start: t, End: t}
+		excluded.normalize(date)
+		day := excluded.Start
+		start := time.Date(day.Year(), day.Month(), day.Day(), eventDate.Start.Hour(), eventDate.Start.Minute(), 0, 0, eventDate.Start.Location())
+		if _, ok := eventDate.occursOn(start); !ok {
+			return fmt.Errorf("%w: excluded date %q is out of recurrence", ErrDateParse, exception)
+		}
+		eventDate.Exceptions = append(eventDate.Exceptions, start)
+	}
+	return nil
 }
 
+// datesRegexp matches bracket block of dates.
+var datesRegexp = regexp.MustCompile(`\[[^\[\]]+\]`)
+
+// rawDates returns dates of the last bracket block in data as they are written.
+func rawDates(data string) []string {
+	blocks := datesRegexp.FindAllString(data, -1)
+	if blocks == nil {
+		return nil
+	}
+	return strings.Split(strings.Trim(blocks[len(blocks)-1], "[]"), ", ")
+}
+
+// weeklyRegexp matches markers of event taking place every week of semester instead of dates.
+var weeklyRegexp = regexp.MustCompile(`^(?i:еженедельно|по расписанию)$`)
+
+// weeklyDate returns *EventDate recurring every week of semester range on weekday of raw event.
+// Semester range and weekday are required, and they are taken from header and row labels.
+func weeklyDate(raw *RawEvent, eventTime *EventTime) (*EventDate, error) {
+	weekday, ok := parseWeekday(raw.weekday)
+	if raw.semester == nil || !ok {
+		return nil, fmt.Errorf("%w: weekly event requires semester range and weekday", ErrMalformedCell)
+	}
+	date := NewEventDate(raw.semester.start, raw.semester.end, eventTime, FrequencyEvery)
+	date.normalize(raw.initialDate)
+	date.Start = date.Start.AddDate(0, 0, (int(weekday)-int(date.Start.Weekday())+7)%7)
+	date.End = date.End.AddDate(0, 0, -((int(date.End.Weekday()) - int(weekday) + 7) % 7))
+	if date.End.Before(date.Start) {
+		return nil, fmt.Errorf("%w: semester range has no %s", ErrMalformedCell, weekday)
+	}
+	return date, nil
+}
+
+// openRangeRegexp matches date range with start date only and optional frequency,
+// e.g. "с 14.09", "с 14.09 до конца семестра" or "с 14.09 ч.н.".
+var openRangeRegexp = regexp.MustCompile(`^(?i:[сc])\s+(\d{2}\.\d{2})(?i:\s+до\s+конца\s+семестра)?(?:\s+(к\.н\.|ч\.н\.))?$`)
+
+// openRangeDate returns *EventDate recurring from start date to the last date of semester range
+// on weekday of start date. Frequency marker "ч.н." stands for every other week, and every week is the default.
+// Semester range is required, and it is taken from header.
+func openRangeDate(raw *RawEvent, start, frequencyMarker string, eventTime *EventTime) (*EventDate, error) {
+	if raw.semester == nil {
+		return nil, fmt.Errorf("%w: open-ended date range requires semester range", ErrMalformedCell)
+	}
+	if !isDate(start) {
+		return nil, fmt.Errorf("%w: incorrect date %q", ErrDateParse, start)
+	}
+	frequency, period := FrequencyEvery, 7
+	if frequencyMarker == "ч.н." {
+		frequency, period = FrequencyThroughout, 14
+	}
+	date := NewEventDate(start, raw.semester.end, eventTime, frequency)
+	date.normalize(raw.initialDate)
+	if date.End.Before(date.Start) {
+		return nil, fmt.Errorf("%w: date %q is after end of semester", ErrDateParse, start)
+	}
+	date.End = date.End.AddDate(0, 0, -((days(date.End) - days(date.Start)) % period))
+	date.OpenEndedRange = true
+	return date, nil
+}
+
+// parityRegexp matches parity of weeks at the end of group of dates, e.g. "(чётная)" or "(нечетная неделя)".
+var parityRegexp = regexp.MustCompile(`\s*\((?i:(не)?ч[её]тн(?:ая|ые)(?:\s+недел[яи])?)\)$`)
+
+// numeratorRegexp matches numerator or denominator week at the end of group of dates, e.g. "(числитель)" or "(знам.)".
+var numeratorRegexp = regexp.MustCompile(`\s*\((?i:(числ(?:итель|\.)|знам(?:енатель|\.)))\)$`)
+
+// numeratorMarkerRegexp matches frequency marker of date range taking place on numerator or denominator weeks,
+// e.g. "числ." in "05.09-26.12 числ.".
+var numeratorMarkerRegexp = regexp.MustCompile(`^(?i:числ(?:итель|\.)|знам(?:енатель|\.))$`)
+
+// parseParity cuts parity of weeks from the end of group of dates and returns it,
+// or empty parity if group has no parity. Numerator and denominator weeks are resolved by numeratorParity.
+func parseParity(group *string, numerator Parity) Parity {
+	if submatches := numeratorRegexp.FindStringSubmatch(*group); submatches != nil {
+		*group = strings.TrimSuffix(*group, submatches[0])
+		return termParity(submatches[1], numerator)
+	}
+	submatches := parityRegexp.FindStringSubmatch(*group)
+	if submatches == nil {
+		return ""
+	}
+	*group = strings.TrimSuffix(*group, submatches[0])
+	if submatches[1] != "" {
+		return ParityOdd
+	}
+	return ParityEven
+}
+
+// numeratorParity returns parity of numerator weeks of raw event, see WithNumeratorParity.
+func (raw *RawEvent) numeratorParity() Parity {
+	if raw.settings().numerator == "" {
+		return ParityOdd
+	}
+	return raw.settings().numerator
+}
+
+// termParity returns parity of weeks of term "числитель" or "знаменатель" of given parity of numerator weeks.
+func termParity(term string, numerator Parity) Parity {
+	if strings.HasPrefix(strings.ToLower(term), "числ") {
+		return numerator
+	}
+	if numerator == ParityOdd {
+		return ParityEven
+	}
+	return ParityOdd
+}
+
+// weekOne returns date in the first week of semester, which is odd week.
+// It is start of semester range from header, or else September 1 of academic year of initial date.
+func (raw *RawEvent) weekOne() time.Time {
+	if raw.semester != nil {
+		start := NewEventDate(raw.semester.start, raw.semester.start, &EventTime{}, FrequencyOnce)
+		start.normalize(raw.initialDate)
+		return start.Start
+	}
+	year := raw.initialDate.Year()
+	if raw.initialDate.Month() < time.August {
+		year--
+	}
+	return time.Date(year, time.September, 1, 0, 0, 0, 0, loc)
+}
+
+// weekParity returns parity of week of date counted from week of weekOne, which is odd week.
+func weekParity(date, weekOne time.Time) Parity {
+	monday := func(t time.Time) int { return days(t) - (int(t.Weekday())+6)%7 }
+	if ((monday(date)-monday(weekOne))/7)%2 == 0 {
+		return ParityOdd
+	}
+	return ParityEven
+}
+
+// alignParity moves start of every other week date range to the first week of given parity
+// and end to the last occurrence of range. It returns false if range has no week of parity.
+func (eventDate *EventDate) alignParity(parity Parity, weekOne time.Time) bool {
+	if weekParity(eventDate.Start, weekOne) != parity {
+		eventDate.Start = eventDate.Start.AddDate(0, 0, 7)
+	}
+	if days(eventDate.End) < days(eventDate.Start) {
+		return false
+	}
+	eventDate.End = eventDate.End.AddDate(0, 0, -((days(eventDate.End) - days(eventDate.Start)) % 14))
+	eventDate.Parity = parity
+	return true
+}
+
+// dateSeparatorRegexp matches comma separating dates of group.
+var dateSeparatorRegexp = regexp.MustCompile(`\s*,\s*`)
+
 // parseDates searches for dates in raw event data and extracts them,
 // returns slice of EventDate and index of first occurrence.
+// Marker "еженедельно" or "по расписанию" in place of dates stands for every week of semester.
+// Groups of dates are separated by semicolons and may end with parity of weeks, e.g. "[14.09, 28.09 (чётная); 21.09 (нечётная)]",
+// or with numerator or denominator week, e.g. "(числитель)". Date range of such group or with numerator or denominator
+// marker, e.g. "05.09-26.12 числ.", recurs every other week from the first week of its parity.
+// Shift is number of time slots the event spans after its own and it is passed to parseTime.
 func parseDates(raw *RawEvent, shift int) ([]EventDate, int, error) {
-	datesRegexp := regexp.MustCompile(`\[.+\]$`)
-	datesIndex := datesRegexp.FindStringIndex(raw.data)[0]
+	datesIndexes := datesRegexp.FindAllStringIndex(raw.data, -1)
+	if datesIndexes == nil {
+		return nil, -1, fmt.Errorf("%w: dates are not found", ErrMalformedCell)
+	}
+	datesIndex, datesEnd := datesIndexes[len(datesIndexes)-1][0], datesIndexes[len(datesIndexes)-1][1]
 
 	eventTime, err := parseTime(raw, shift)
 	if err != nil {
 		return nil, -1, fmt.Errorf("parseTime error: %w", err)
 	}
 
-	// [09.09-28.10 к.н., 11.11, 18.11]
-	datesString := strings.Trim(raw.data[datesIndex:], "[]")
+	// [09.09-28.10 к.н., 11.11, 18.11, 25.11 с 14:00]
+	datesString := strings.Trim(raw.data[datesIndex:datesEnd], "[]")
+	datesString = dashReplacer.Replace(datesString)
+	if raw.settings().compactDates {
+		datesString = dateSpaceRegexp.ReplaceAllString(datesString, "$1$2$3")
+	}
+	datesString = rangeDashRegexp.ReplaceAllString(datesString, "$1-$2")
+	dates := make([]EventDate, 0)
+	for _, group := range strings.Split(datesString, ";") {
+		parity := parseParity(&group, raw.numeratorParity())
+		if strings.TrimSpace(group) == "" {
+			continue
+		}
+		groupDates, err := parseGroupDates(raw, group, eventTime, parity)
+		if err != nil {
+			return nil, -1, err
+		}
+		for i := range groupDates {
+			if parity != "" {
+				groupDates[i].Parity = parity
+			}
+		}
+		dates = append(dates, groupDates...)
+	}
+	return dates, datesIndex, nil
+}
+
+// parseGroupDates parses comma-separated dates of group with default time of eventTime.
+// Date ranges of group with parity of weeks recur every other week from the first week of parity,
+// and they may have no frequency marker, e.g. "05.09-26.12 (чётная)".
+func parseGroupDates(raw *RawEvent, group string, eventTime *EventTime, parity Parity) ([]EventDate, error) {
 	dates := make([]EventDate, 0)
-	for _, complexDate := range strings.Split(datesString, ", ") {
+	for _, complexDate := range dateSeparatorRegexp.Split(strings.TrimSpace(group), -1) {
+		dateTime, openEnded, err := parseOpenEnded(&complexDate)
+		if err != nil {
+			return nil, err
+		}
+		if dateTime == nil {
+			if dateTime, err = parseDateTime(&complexDate, raw.settings().timeLayouts); err != nil {
+				return nil, err
+			}
+		}
+		if dateTime == nil {
+			dateTime = eventTime
+		}
+		exceptions, err := parseExceptions(&complexDate)
+		if err != nil {
+			return nil, err
+		}
+		if complexDate == "" && exceptions != nil {
+			// "еженедельно, кроме 12.10" excludes dates from preceding date
+			if len(dates) == 0 {
+				return nil, fmt.Errorf("%w: excluded dates without date", ErrDateParse)
+			}
+			if err := dates[len(dates)-1].exclude(exceptions, raw.initialDate); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if weeklyRegexp.MatchString(complexDate) {
+			date, err := weeklyDate(raw, dateTime)
+			if err != nil {
+				return nil, err
+			}
+			date.OpenEnded = openEnded
+			if err := date.exclude(exceptions, raw.initialDate); err != nil {
+				return nil, err
+			}
+			dates = append(dates, *date)
+			continue
+		}
+		if submatches := openRangeRegexp.FindStringSubmatch(complexDate); submatches != nil {
+			date, err := openRangeDate(raw, submatches[1], submatches[2], dateTime)
+			if err != nil {
+				return nil, err
+			}
+			date.OpenEnded = openEnded
+			if err := date.exclude(exceptions, raw.initialDate); err != nil {
+				return nil, err
+			}
+			dates = append(dates, *date)
+			continue
+		}
+
 		splitDate := strings.Split(complexDate, " ")
 		var date *EventDate
 
-		if dateLength := len(splitDate); dateLength == 1 {
-			date = NewEventDate(splitDate[0], splitDate[0], eventTime, "once")
-		} else if dateLength == 2 {
-			dateFrequency := splitDate[1]
+		dateLength := len(splitDate)
+		if dateLength == 1 && isDate(splitDate[0]) {
+			date = NewEventDate(splitDate[0], splitDate[0], dateTime, FrequencyOnce)
+		} else if dateLength == 2 || (dateLength == 1 && parity != "") {
+			dateFrequency := ""
+			if dateLength == 2 {
+				dateFrequency = splitDate[1]
+			}
 			splitDate := strings.Split(splitDate[0], "-")
-			if dateFrequency == "к.н." {
-				date = NewEventDate(splitDate[0], splitDate[1], eventTime, "every")
-			} else if dateFrequency == "ч.н." {
-				date = NewEventDate(splitDate[0], splitDate[1], eventTime, "throughout")
+			if len(splitDate) != 2 || !isDate(splitDate[0]) || !isDate(splitDate[1]) {
+				return nil, fmt.Errorf("%w: incorrect date range %q", ErrDateParse, complexDate)
 			}
+			if dateFrequency == "к.н." && parity == "" {
+				date = NewEventDate(splitDate[0], splitDate[1], dateTime, FrequencyEvery)
+			} else if dateFrequency == "к.н." || dateFrequency == "ч.н." || dateFrequency == "" || numeratorMarkerRegexp.MatchString(dateFrequency) {
+				date = NewEventDate(splitDate[0], splitDate[1], dateTime, FrequencyThroughout)
+			}
+		}
+		if date == nil {
+			return nil, fmt.Errorf("%w: incorrect date %q", ErrDateParse, complexDate)
 		}
+		date.OpenEnded = openEnded
 		date.normalize(raw.initialDate)
+		rangeParity := parity
+		if marker := splitDate[len(splitDate)-1]; numeratorMarkerRegexp.MatchString(marker) {
+			rangeParity = termParity(marker, raw.numeratorParity())
+		}
+		if date.Frequency == FrequencyThroughout && rangeParity != "" && !date.alignParity(rangeParity, raw.weekOne()) {
+			return nil, fmt.Errorf("%w: date range %q has no %s weeks", ErrDateParse, complexDate, rangeParity)
+		}
+		if err := date.exclude(exceptions, raw.initialDate); err != nil {
+			return nil, err
+		}
 		dates = append(dates, *date)
 	}
-	return dates, datesIndex, nil
+	return dates, nil
 }
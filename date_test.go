@@ -3,7 +3,10 @@
 package scheduleparser
 
 import (
+	"bytes"
+	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,7 +15,7 @@ import (
 
 func TestEventDate_normalize(t *testing.T) {
 	t.Run("FutureDate", func(t *testing.T) {
-		eventDate := EventDate{time.Date(0, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(0, 5, 1, 0, 0, 0, 0, time.UTC), "once"}
+		eventDate := EventDate{Start: time.Date(0, 5, 1, 0, 0, 0, 0, time.UTC), End: time.Date(0, 5, 1, 0, 0, 0, 0, time.UTC), Frequency: "once"}
 		date := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
 		eventDate.normalize(date)
 		if year := eventDate.Start.Year(); year != 2000 {
@@ -21,7 +24,7 @@ func TestEventDate_normalize(t *testing.T) {
 	})
 
 	t.Run("PastDate", func(t *testing.T) {
-		eventDate := EventDate{time.Date(0, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(0, 5, 1, 0, 0, 0, 0, time.UTC), "once"}
+		eventDate := EventDate{Start: time.Date(0, 5, 1, 0, 0, 0, 0, time.UTC), End: time.Date(0, 5, 1, 0, 0, 0, 0, time.UTC), Frequency: "once"}
 		date := time.Date(2000, 6, 1, 0, 0, 0, 0, time.UTC)
 		eventDate.normalize(date)
 		if year := eventDate.Start.Year(); year != 2001 {
@@ -49,24 +52,92 @@ func Test_parseDates(t *testing.T) {
 		{
 			"FrequencyEvery",
 			args{
-				&RawEvent{"Title. Teacher. Type. Location. [05.09-05.12 к.н.]", pdf.Point{X: 46, Y: 0}, initialDate},
+				&RawEvent{data: "Title. Teacher. Type. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
 				0,
 			},
 			[]EventDate{
-				{time.Date(2000, 9, 5, 8, 30, 0, 0, loc), time.Date(2000, 12, 5, 10, 10, 0, 0, loc), "every"},
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every"},
 			},
 			32,
 			false,
 		},
+		{
+			"Weekly",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [еженедельно]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate, weekday: "Tuesday", semester: &semester{"01.09", "28.12"}},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 26, 10, 10, 0, 0, loc), Frequency: "every"},
+			},
+			32,
+			false,
+		},
+		{
+			"BySchedule",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [по расписанию]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate, weekday: "Monday", semester: &semester{"01.09", "28.12"}},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 4, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 25, 10, 10, 0, 0, loc), Frequency: "every"},
+			},
+			32,
+			false,
+		},
+		{
+			"OpenEndedRange",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [с 14.09 до конца семестра]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate, semester: &semester{"01.09", "28.12"}},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 14, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 28, 10, 10, 0, 0, loc), Frequency: "every", OpenEndedRange: true},
+			},
+			32,
+			false,
+		},
+		{
+			"OpenEndedRangeThroughout",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [с 14.09 ч.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate, semester: &semester{"01.09", "28.12"}},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 14, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 21, 10, 10, 0, 0, loc), Frequency: "throughout", OpenEndedRange: true},
+			},
+			32,
+			false,
+		},
+		{
+			"OpenEndedRangeWithoutSemester",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [с 14.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			nil,
+			-1,
+			true,
+		},
+		{
+			"WeeklyWithoutSemester",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [еженедельно]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate, weekday: "Monday"},
+				0,
+			},
+			nil,
+			-1,
+			true,
+		},
 		{
 			"FrequencyOnce",
 			args{
-				&RawEvent{"Title. Teacher. Type. Subgroup. Location. [05.12, 19.12]", pdf.Point{X: 233, Y: 0}, initialDate},
+				&RawEvent{data: "Title. Teacher. Type. Subgroup. Location. [05.12, 19.12]", position: pdf.Point{X: 233, Y: 0}, initialDate: initialDate},
 				1,
 			},
 			[]EventDate{
-				{time.Date(2000, 12, 5, 12, 20, 0, 0, loc), time.Date(2000, 12, 5, 15, 50, 0, 0, loc), "once"},
-				{time.Date(2000, 12, 19, 12, 20, 0, 0, loc), time.Date(2000, 12, 19, 15, 50, 0, 0, loc), "once"},
+				{Start: time.Date(2000, 12, 5, 12, 20, 0, 0, loc), End: time.Date(2000, 12, 5, 15, 50, 0, 0, loc), Frequency: "once"},
+				{Start: time.Date(2000, 12, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 12, 19, 15, 50, 0, 0, loc), Frequency: "once"},
 			},
 			42,
 			false,
@@ -74,11 +145,11 @@ func Test_parseDates(t *testing.T) {
 		{
 			"FrequencyThroughout",
 			args{
-				&RawEvent{"Title. Teacher. Type. Subgroup. Location. [26.10-21.12 ч.н.]", pdf.Point{X: 420, Y: 0}, initialDate},
+				&RawEvent{data: "Title. Teacher. Type. Subgroup. Location. [26.10-21.12 ч.н.]", position: pdf.Point{X: 420, Y: 0}, initialDate: initialDate},
 				1,
 			},
 			[]EventDate{
-				{time.Date(2000, 10, 26, 16, 0, 0, 0, loc), time.Date(2000, 12, 21, 19, 30, 0, 0, loc), "throughout"},
+				{Start: time.Date(2000, 10, 26, 16, 0, 0, 0, loc), End: time.Date(2000, 12, 21, 19, 30, 0, 0, loc), Frequency: "throughout"},
 			},
 			42,
 			false,
@@ -86,20 +157,217 @@ func Test_parseDates(t *testing.T) {
 		{
 			"FrequencyHybrid",
 			args{
-				&RawEvent{"Title. Teacher. Type. Subgroup. Location. [02.09-28.10 к.н., 11.11]", pdf.Point{X: 233, Y: 0}, initialDate},
+				&RawEvent{data: "Title. Teacher. Type. Subgroup. Location. [02.09-28.10 к.н., 11.11]", position: pdf.Point{X: 233, Y: 0}, initialDate: initialDate},
 				0,
 			},
 			[]EventDate{
-				{time.Date(2000, 9, 2, 12, 20, 0, 0, loc), time.Date(2000, 10, 28, 14, 0, 0, 0, loc), "every"},
-				{time.Date(2000, 11, 11, 12, 20, 0, 0, loc), time.Date(2000, 11, 11, 14, 0, 0, 0, loc), "once"},
+				{Start: time.Date(2000, 9, 2, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 28, 14, 0, 0, 0, loc), Frequency: "every"},
+				{Start: time.Date(2000, 11, 11, 12, 20, 0, 0, loc), End: time.Date(2000, 11, 11, 14, 0, 0, 0, loc), Frequency: "once"},
 			},
 			42,
 			false,
 		},
+		{
+			"FollowedByNote",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [05.09-05.12 к.н.] (перенос на 20.10)", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every"},
+			},
+			32,
+			false,
+		},
+		{
+			"DashVariants",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [14.09–28.12 к.н., 15.09−29.12 ч.н., 16.09‑30.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 14, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 28, 10, 10, 0, 0, loc), Frequency: "every"},
+				{Start: time.Date(2000, 9, 15, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 29, 10, 10, 0, 0, loc), Frequency: "throughout"},
+				{Start: time.Date(2000, 9, 16, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 30, 10, 10, 0, 0, loc), Frequency: "every"},
+			},
+			32,
+			false,
+		},
+		{
+			"OpenEnded",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [05.09-05.12 к.н., 12.12 с 14:00 до конца дня]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every"},
+				{Start: time.Date(2000, 12, 12, 14, 0, 0, 0, loc), End: time.Date(2000, 12, 12, 14, 0, 0, 0, loc), Frequency: "once", OpenEnded: true},
+			},
+			32,
+			false,
+		},
+		{
+			"OpenEndedTimeError",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [12.12 с 25:00]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			nil,
+			-1,
+			true,
+		},
+		{
+			"Exception",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [05.09-05.12 к.н. кроме 10.10 и 17.10, 12.12]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every", Exceptions: []time.Time{
+					time.Date(2000, 10, 10, 8, 30, 0, 0, loc), time.Date(2000, 10, 17, 8, 30, 0, 0, loc),
+				}},
+				{Start: time.Date(2000, 12, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 12, 10, 10, 0, 0, loc), Frequency: "once"},
+			},
+			32,
+			false,
+		},
+		{
+			"WeeklyException",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [еженедельно, кроме 10.10]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate, weekday: "Tuesday", semester: &semester{"01.09", "28.12"}},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 26, 10, 10, 0, 0, loc), Frequency: "every", Exceptions: []time.Time{
+					time.Date(2000, 10, 10, 8, 30, 0, 0, loc),
+				}},
+			},
+			32,
+			false,
+		},
+		{
+			"ExceptionOutOfRecurrenceError",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [05.09-05.12 к.н., кроме 11.10]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			nil,
+			-1,
+			true,
+		},
+		{
+			"TimeRange",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [12.09 8-9:30, 19.09 8:00-9:30, 26.09 8-9]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 12, 8, 0, 0, 0, loc), End: time.Date(2000, 9, 12, 9, 30, 0, 0, loc), Frequency: "once"},
+				{Start: time.Date(2000, 9, 19, 8, 0, 0, 0, loc), End: time.Date(2000, 9, 19, 9, 30, 0, 0, loc), Frequency: "once"},
+				{Start: time.Date(2000, 9, 26, 8, 0, 0, 0, loc), End: time.Date(2000, 9, 26, 9, 0, 0, 0, loc), Frequency: "once"},
+			},
+			32,
+			false,
+		},
+		{
+			"TimeRangeError",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [12.09 8-25]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			nil,
+			-1,
+			true,
+		},
+		{
+			"SpacedRange",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [05.09 - 05.12 к.н., 06.09 –06.12 ч.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every"},
+				{Start: time.Date(2000, 9, 6, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 6, 10, 10, 0, 0, loc), Frequency: "throughout"},
+			},
+			32,
+			false,
+		},
+		{
+			"UnspacedRange",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [05.09—05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every"},
+			},
+			32,
+			false,
+		},
+		{
+			"ParityGroups",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [14.09,28.09 (чётная); 21.09 с 14:00 (нечётная)]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 14, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 14, 10, 10, 0, 0, loc), Frequency: "once", Parity: ParityEven},
+				{Start: time.Date(2000, 9, 28, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 28, 10, 10, 0, 0, loc), Frequency: "once", Parity: ParityEven},
+				{Start: time.Date(2000, 9, 21, 14, 0, 0, 0, loc), End: time.Date(2000, 9, 21, 14, 0, 0, 0, loc), Frequency: "once", OpenEnded: true, Parity: ParityOdd},
+			},
+			32,
+			false,
+		},
+		{
+			"ParityRanges",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [05.09-26.12 (нечётная); 05.09-26.12 ч.н. (чётная)]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 19, 10, 10, 0, 0, loc), Frequency: "throughout", Parity: ParityOdd},
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 26, 10, 10, 0, 0, loc), Frequency: "throughout", Parity: ParityEven},
+			},
+			32,
+			false,
+		},
+		{
+			"GroupsWithoutParity",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [05.09-05.12 ч.н.; 12.12]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			[]EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "throughout"},
+				{Start: time.Date(2000, 12, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 12, 10, 10, 0, 0, loc), Frequency: "once"},
+			},
+			32,
+			false,
+		},
+		{
+			"IncorrectDateError",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location. [05.09-05.12 н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			nil,
+			-1,
+			true,
+		},
+		{
+			"DatesNotFoundError",
+			args{
+				&RawEvent{data: "Title. Teacher. Type. Location.", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+				0,
+			},
+			nil,
+			-1,
+			true,
+		},
 		{
 			"ParseTimeError",
 			args{
-				&RawEvent{"Subject. Teacher. Type. Subgroup. Location. [02.09-28.10 к.н., 11.11]", pdf.Point{X: 233, Y: 0}, initialDate},
+				&RawEvent{data: "Subject. Teacher. Type. Subgroup. Location. [02.09-28.10 к.н., 11.11]", position: pdf.Point{X: 233, Y: 0}, initialDate: initialDate},
 				6,
 			},
 			nil,
@@ -124,3 +392,193 @@ func Test_parseDates(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseDates_openEndedRange(t *testing.T) {
+	raw := &RawEvent{data: "Title. Teacher. Type. Location. [с 14.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: time.Date(2000, 8, 20, 0, 0, 0, 0, loc), semester: &semester{"01.09", "28.12"}}
+	dates, _, err := parseDates(raw, 0)
+	if err != nil {
+		t.Fatalf("parseDates() error = %v", err)
+	}
+	occurrences := Occurrences(Event{Dates: dates})
+	if len(occurrences) != 16 {
+		t.Fatalf("len(Occurrences()) = %d, want %d", len(occurrences), 16)
+	}
+	if last, want := occurrences[len(occurrences)-1].Date, time.Date(2000, 12, 28, 0, 0, 0, 0, loc); !last.Equal(want) {
+		t.Errorf("last occurrence = %v, want %v", last, want)
+	}
+}
+
+func Test_parseDates_numerator(t *testing.T) {
+	tests := []struct {
+		name      string
+		dates     string
+		numerator Parity
+		want      []EventDate
+	}{
+		{
+			"NumeratorOdd", "[05.09-26.12 числ.]", "",
+			[]EventDate{{Start: time.Date(2000, 9, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 19, 10, 10, 0, 0, loc), Frequency: FrequencyThroughout, Parity: ParityOdd}},
+		},
+		{
+			"NumeratorEven", "[05.09-26.12 числитель]", ParityEven,
+			[]EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 26, 10, 10, 0, 0, loc), Frequency: FrequencyThroughout, Parity: ParityEven}},
+		},
+		{
+			"DenominatorOdd", "[05.09-26.12 знам.]", "",
+			[]EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 26, 10, 10, 0, 0, loc), Frequency: FrequencyThroughout, Parity: ParityEven}},
+		},
+		{
+			"DenominatorEven", "[05.09-26.12 знаменатель]", ParityEven,
+			[]EventDate{{Start: time.Date(2000, 9, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 19, 10, 10, 0, 0, loc), Frequency: FrequencyThroughout, Parity: ParityOdd}},
+		},
+		{
+			"NumeratorGroup", "[12.09, 26.09 (числитель)]", ParityEven,
+			[]EventDate{
+				{Start: time.Date(2000, 9, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 12, 10, 10, 0, 0, loc), Frequency: FrequencyOnce, Parity: ParityEven},
+				{Start: time.Date(2000, 9, 26, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 26, 10, 10, 0, 0, loc), Frequency: FrequencyOnce, Parity: ParityEven},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := &RawEvent{
+				data: "Title. Teacher. Type. Location. " + tt.dates, position: pdf.Point{X: 46, Y: 0},
+				initialDate: time.Date(2000, 8, 20, 0, 0, 0, 0, loc), semester: &semester{"01.09", "28.12"}, config: &rawEventConfig{numerator: tt.numerator},
+			}
+			got, _, err := parseDates(raw, 0)
+			if err != nil {
+				t.Fatalf("parseDates() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseDates_numeratorExpansion(t *testing.T) {
+	for _, dates := range []string{"[05.09-26.12 (числитель)]", "[05.09-26.12 числ.]"} {
+		raw := &RawEvent{
+			data: "Title. Teacher. Type. Location. " + dates, position: pdf.Point{X: 46, Y: 0},
+			initialDate: time.Date(2000, 8, 20, 0, 0, 0, 0, loc), semester: &semester{"01.09", "28.12"},
+		}
+		eventDates, _, err := parseDates(raw, 0)
+		if err != nil {
+			t.Fatalf("parseDates(%q) error = %v", dates, err)
+		}
+		event := Event{Title: "Title", Dates: eventDates}
+		occurrences := Occurrences(event)
+		if len(occurrences) != 8 {
+			t.Fatalf("len(Occurrences(%q)) = %d, want %d", dates, len(occurrences), 8)
+		}
+		for i, o := range occurrences {
+			if want := time.Date(2000, 9, 12+14*i, 0, 0, 0, 0, loc); !o.Date.Equal(want) {
+				t.Errorf("Occurrences(%q)[%d].Date = %v, want %v", dates, i, o.Date, want)
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := WriteICS([]Event{event}, &buf); err != nil {
+			t.Fatalf("WriteICS() error = %v", err)
+		}
+		want := "DTSTART:20000912T053000Z\r\nDTEND:20000912T071000Z\r\nRRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=20001219T071000Z\r\n"
+		if got := buf.String(); !strings.Contains(got, want) {
+			t.Errorf("WriteICS(%q) = %q, want to contain %q", dates, got, want)
+		}
+	}
+}
+
+func TestEventDate_MarshalJSON(t *testing.T) {
+	eventDate := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery}
+	want := `{"date":"2000-09-05","start":"08:30","end":"10:10","week":36,"frequency":"every","until":"2000-12-05"}`
+
+	got, err := json.Marshal(eventDate)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+
+	var decoded EventDate
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, eventDate) {
+		t.Errorf("json.Unmarshal() = %v, want %v", decoded, eventDate)
+	}
+}
+
+func TestEventDate_MarshalJSON_once(t *testing.T) {
+	eventDate := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce}
+	want := `{"date":"2000-09-05","start":"08:30","end":"10:10","week":36,"frequency":"once"}`
+
+	got, err := json.Marshal(eventDate)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+
+	var decoded EventDate
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, eventDate) {
+		t.Errorf("json.Unmarshal() = %v, want %v", decoded, eventDate)
+	}
+}
+
+func TestEventDate_MarshalJSON_optional(t *testing.T) {
+	eventDate := EventDate{
+		Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery,
+		Exceptions: []time.Time{time.Date(2000, 10, 10, 8, 30, 0, 0, loc)}, Parity: ParityOdd,
+	}
+	want := `{"date":"2000-09-05","start":"08:30","end":"10:10","week":36,"frequency":"every","until":"2000-12-05","exceptions":["2000-10-10"],"parity":"odd"}`
+
+	got, err := json.Marshal(eventDate)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+
+	var decoded EventDate
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded, eventDate) {
+		t.Errorf("json.Unmarshal() = %v, want %v", decoded, eventDate)
+	}
+}
+
+func TestEventDate_MarshalJSON_location(t *testing.T) {
+	eventDate := EventDate{Start: time.Date(2000, 9, 5, 5, 30, 0, 0, time.UTC), End: time.Date(2000, 9, 5, 7, 10, 0, 0, time.UTC), Frequency: FrequencyOnce}
+	want := `{"date":"2000-09-05","start":"08:30","end":"10:10","week":36,"frequency":"once"}`
+
+	got, err := json.Marshal(eventDate)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+
+	var decoded EventDate
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !decoded.Start.Equal(eventDate.Start) || !decoded.End.Equal(eventDate.End) {
+		t.Errorf("json.Unmarshal() = %v, want %v", decoded, eventDate)
+	}
+}
+
+func TestEventDate_UnmarshalJSON(t *testing.T) {
+	var eventDate EventDate
+	err := json.Unmarshal([]byte(`{"date":"05.09","start":"08:30","end":"10:10","until":"2000-12-05"}`), &eventDate)
+	if err == nil {
+		t.Errorf("json.Unmarshal() error = %v, wantErr %v", err, true)
+	}
+}
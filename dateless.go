@@ -0,0 +1,58 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"math"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// DatelessCells is handling of cells without dates in brackets, e.g. informational cell "Классный час".
+type DatelessCells int
+
+const (
+	// DatelessMerge merges cell without dates into the next cell, because cell lasts until its dates.
+	DatelessMerge DatelessCells = iota
+	// DatelessEvent forms event of cell without dates with Dateless flag.
+	DatelessEvent
+	// DatelessSkip skips cell without dates.
+	DatelessSkip
+)
+
+// maxCellIndent is maximum distance between X coordinates of lines of the same cell.
+const maxCellIndent = 40
+
+// endsDateless reports whether text starting new line is beyond current raw event without dates,
+// i.e. it is higher than the first line of raw event or in another column, so that it starts the next cell.
+func (s *rawEventScanner) endsDateless(text pdf.Text) bool {
+	return !strings.Contains(s.data, "[") && (text.Y > s.position.Y || math.Abs(text.X-s.position.X) > maxCellIndent)
+}
+
+// appendDateless forms raw event without dates from current data or skips it according to options.
+func (s *rawEventScanner) appendDateless() error {
+	if s.p.datelessCells == DatelessSkip {
+		s.data = ""
+		s.current.segments, s.current.texts = nil, nil
+		return nil
+	}
+	if err := s.appendRawEvent(); err != nil {
+		return err
+	}
+	s.rawEvents[len(s.rawEvents)-1].dateless = true
+	return nil
+}
+
+// parseDatelessEvent parses *RawEvent of cell without dates and returns *Event with Dateless flag.
+// Whole text of cell becomes title.
+func parseDatelessEvent(raw *RawEvent) *Event {
+	return &Event{
+		Title:    strings.TrimSuffix(strings.Join(strings.Fields(raw.data), " "), "."),
+		Weekday:  raw.weekday,
+		Group:    raw.group,
+		Dateless: true,
+
+		Highlighted: raw.highlighted,
+	}
+}
@@ -0,0 +1,57 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+func TestWithDatelessCells(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 500, S: "Классный час"},
+		{X: 46, Y: 490, S: "куратора."},
+		{X: 139, Y: 500, S: "Next. лекции. Location. [06.09]"},
+		{X: 232, Y: 500, S: "Информация"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+	content := &reader.Content{Texts: texts}
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		want     []string
+		dateless []bool
+	}{
+		{"Merge", nil, []string{"Классный час куратора"}, []bool{false}},
+		{"Event", []Option{WithDatelessCells(DatelessEvent)}, []string{"Классный час куратора", "Next", "Информация"}, []bool{true, false, true}},
+		{"Skip", []Option{WithDatelessCells(DatelessSkip)}, []string{"Next"}, []bool{false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewParser(tt.opts...).parseText(content, initialDate, "")
+			if err != nil {
+				t.Fatalf("Parser.parseText() error = %v", err)
+			}
+			if len(schedule.Events) != len(tt.want) {
+				t.Fatalf("len(events) = %d, want %d", len(schedule.Events), len(tt.want))
+			}
+			for i, event := range schedule.Events {
+				if event.Title != tt.want[i] || event.Dateless != tt.dateless[i] {
+					t.Errorf("events[%d] = %q dateless %v, want %q dateless %v", i, event.Title, event.Dateless, tt.want[i], tt.dateless[i])
+				}
+				if event.Dateless && len(event.Dates) != 0 {
+					t.Errorf("events[%d].Dates = %v, want none", i, event.Dates)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"regexp"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Format is a layout of schedule pdf content.
+type Format string
+
+const (
+	FormatUnknown  Format = "unknown"  // layout isn't recognized or cues contradict each other
+	FormatWeekly   Format = "weekly"   // weekly grid of classes with weekday rows
+	FormatExam     Format = "exam"     // exam session with consultations and exams
+	FormatPractice Format = "practice" // practice blocks lasting several days
+)
+
+// formatRegexps match header keywords of formats.
+var formatRegexps = map[Format]*regexp.Regexp{
+	FormatWeekly:   regexp.MustCompile(`(?i)расписание\s+(?:учебных\s+)?занятий`),
+	FormatExam:     regexp.MustCompile(`(?i)экзаменационн|сесси[яи]`),
+	FormatPractice: regexp.MustCompile(`(?i)практик`),
+}
+
+// DetectFormat classifies layout of pdf content by header keywords and weekday labels of rows.
+// Header keywords of a single format determine it, and weekday labels indicate weekly grid
+// when header has no keywords. FormatUnknown is returned when cues are absent or contradict each other.
+// ErrNoText is returned when texts are empty.
+func DetectFormat(texts []pdf.Text) (Format, error) {
+	if len(texts) == 0 {
+		return FormatUnknown, ErrNoText
+	}
+	found := make(map[Format]bool)
+	for _, line := range getHeaderLines(texts) {
+		for format, re := range formatRegexps {
+			if re.MatchString(line) {
+				found[format] = true
+			}
+		}
+	}
+	// "расписание занятий" is also title of exam and practice schedules, so specific keywords win.
+	if found[FormatExam] || found[FormatPractice] {
+		delete(found, FormatWeekly)
+	}
+	switch len(found) {
+	case 0:
+		if len(getWeekdayLabels(texts)) > 0 {
+			return FormatWeekly, nil
+		}
+		return FormatUnknown, nil
+	case 1:
+		for format := range found {
+			return format, nil
+		}
+	}
+	return FormatUnknown, nil
+}
@@ -0,0 +1,81 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		texts   []pdf.Text
+		want    Format
+		wantErr error
+	}{
+		{"WeeklyFixture", loadFixture(t, filepath.Join("testdata", "schedule.json")), FormatWeekly, nil},
+		{"ExamFixture", loadFixture(t, filepath.Join("testdata", "exam.json")), FormatExam, nil},
+		{
+			"Weekly",
+			[]pdf.Text{
+				{X: 300, Y: 570, S: "РАСПИСАНИЕ ЗАНЯТИЙ"},
+				{X: 46, Y: 500, S: "История. лекции. 101. [05.09-05.12 к.н.]"},
+			},
+			FormatWeekly,
+			nil,
+		},
+		{
+			"WeekdayLabels",
+			[]pdf.Text{
+				{X: 10, Y: 500, S: "Понедельник"},
+				{X: 46, Y: 500, S: "История. лекции. 101. [05.09-05.12 к.н.]"},
+			},
+			FormatWeekly,
+			nil,
+		},
+		{
+			"Exam",
+			[]pdf.Text{
+				{X: 300, Y: 570, S: "РАСПИСАНИЕ ЗАНЯТИЙ"},
+				{X: 300, Y: 560, S: "Экзаменационная сессия"},
+				{X: 46, Y: 500, S: "История. Иванов И.И. экзамен. 101. [12.01]"},
+			},
+			FormatExam,
+			nil,
+		},
+		{
+			"Practice",
+			[]pdf.Text{
+				{X: 300, Y: 570, S: "Учебная практика"},
+				{X: 46, Y: 500, S: "Учебная практика. Иванов И.И. практика. [02.07-15.07]"},
+			},
+			FormatPractice,
+			nil,
+		},
+		{
+			"Contradicting",
+			[]pdf.Text{
+				{X: 300, Y: 570, S: "Экзаменационная сессия"},
+				{X: 300, Y: 560, S: "Производственная практика"},
+			},
+			FormatUnknown,
+			nil,
+		},
+		{"NoText", []pdf.Text{}, FormatUnknown, ErrNoText},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectFormat(tt.texts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("DetectFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("DetectFormat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Equal reports whether event and other have equal fields.
+// Dates are compared regardless of their order and datetimes are compared as instants regardless of location.
+func (event Event) Equal(other Event) bool {
+	return event.EqualIgnoring(other)
+}
+
+// EqualIgnoring is Equal that ignores fields with given names of Event struct, e.g. "Location" or "Note".
+// Names that aren't fields of Event are ignored as well. It can be passed to WithComparator, e.g.
+//
+//	WithComparator(func(a, b Event) bool { return a.EqualIgnoring(b, "Location") })
+func (event Event) EqualIgnoring(other Event, fields ...string) bool {
+	return reflect.DeepEqual(normalized(event, fields), normalized(other, fields))
+}
+
+// normalized returns clone of event with zero ignored fields, empty slices replaced with nil and dates sorted in UTC,
+// so that equal events are deeply equal.
+func normalized(event Event, ignored []string) Event {
+	event = event.Clone()
+	for _, slice := range []*[]string{&event.RawDates, &event.Subgroups} {
+		if len(*slice) == 0 {
+			*slice = nil
+		}
+	}
+	if len(event.Dates) == 0 {
+		event.Dates = nil
+	}
+	value := reflect.ValueOf(&event).Elem()
+	for _, name := range ignored {
+		if field := value.FieldByName(name); field.IsValid() && field.CanSet() {
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+	for i := range event.Dates {
+		date := &event.Dates[i]
+		date.Start, date.End = date.Start.UTC(), date.End.UTC()
+		if len(date.Exceptions) == 0 {
+			date.Exceptions = nil
+		}
+		for j := range date.Exceptions {
+			date.Exceptions[j] = date.Exceptions[j].UTC()
+		}
+	}
+	sort.SliceStable(event.Dates, func(i, j int) bool {
+		a, b := event.Dates[i], event.Dates[j]
+		if !a.Start.Equal(b.Start) {
+			return a.Start.Before(b.Start)
+		}
+		if !a.End.Equal(b.End) {
+			return a.End.Before(b.End)
+		}
+		return a.Frequency < b.Frequency
+	})
+	return event
+}
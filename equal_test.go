@@ -0,0 +1,58 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEvent_EqualIgnoring(t *testing.T) {
+	first := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce}
+	second := EventDate{Start: time.Date(2000, 9, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 12, 10, 10, 0, 0, loc), Frequency: FrequencyOnce}
+	event := Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lecture", Location: "101", Dates: []EventDate{first, second}}
+
+	reordered := event.Clone()
+	reordered.Dates = []EventDate{second, first}
+	utc := event.Clone()
+	for i := range utc.Dates {
+		utc.Dates[i].Start, utc.Dates[i].End = utc.Dates[i].Start.UTC(), utc.Dates[i].End.UTC()
+	}
+	relocated := event.Clone()
+	relocated.Location, relocated.Note = "202", "note"
+	moved := event.Clone()
+	moved.Dates = []EventDate{first}
+
+	tests := []struct {
+		name   string
+		other  Event
+		fields []string
+		want   bool
+	}{
+		{"Same", event, nil, true},
+		{"ReorderedDates", reordered, nil, true},
+		{"UTCDates", utc, nil, true},
+		{"DifferentLocation", relocated, nil, false},
+		{"IgnoredLocationAndNote", relocated, []string{"Location", "Note"}, true},
+		{"IgnoredLocationOnly", relocated, []string{"Location"}, false},
+		{"UnknownField", relocated, []string{"Room"}, false},
+		{"DifferentDates", moved, []string{"Location"}, false},
+		{"IgnoredDates", moved, []string{"Dates"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := event.EqualIgnoring(tt.other, tt.fields...); got != tt.want {
+				t.Errorf("Event.EqualIgnoring(%v) = %v, want %v", tt.fields, got, tt.want)
+			}
+			if tt.fields == nil {
+				if got := event.Equal(tt.other); got != tt.want {
+					t.Errorf("Event.Equal() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+	if !reflect.DeepEqual(event.Dates, []EventDate{first, second}) {
+		t.Errorf("event.Dates = %v after comparison, want %v", event.Dates, []EventDate{first, second})
+	}
+}
@@ -0,0 +1,72 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTooManyEvents is returned when pdf content has more events than allowed by WithMaxEvents.
+	ErrTooManyEvents = errors.New("too many events")
+	// ErrCellTooLong is returned when event cell is longer than allowed by WithMaxCellLength.
+	ErrCellTooLong = errors.New("event cell is too long")
+	// ErrNoText is returned when no text is extracted from pdf content,
+	// e.g. when pdf file consists of scanned images only.
+	ErrNoText = errors.New("no text in pdf content")
+	// ErrTypeNotFound is returned when event cell has no known type keyword.
+	ErrTypeNotFound = errors.New("schedule event type is not found")
+	// ErrDateParse is returned when date or time in event cell is incorrect.
+	ErrDateParse = errors.New("date parse error")
+	// ErrMalformedCell is returned when event cell misses required parts or doesn't fit its position.
+	ErrMalformedCell = errors.New("malformed event cell")
+	// ErrAmbiguousSplit is returned when title and teacher of event can't be told apart with WithStrictSplit.
+	ErrAmbiguousSplit = errors.New("ambiguous title and teacher split")
+	// ErrTooManyOccurrences is returned when event occurs more times than allowed by WithMaxOccurrences.
+	ErrTooManyOccurrences = errors.New("too many event occurrences")
+	// ErrLowConfidence is passed to error handler for events excluded by WithMinConfidence.
+	ErrLowConfidence = errors.New("event confidence is too low")
+	// ErrOutOfValidity is passed to error handler for events with dates outside of validity period of schedule.
+	// Such events are kept in output.
+	ErrOutOfValidity = errors.New("event date is out of validity period")
+)
+
+// DateSpanError is returned when date range of event is longer than allowed by WithMaxDateSpan.
+type DateSpanError struct {
+	Event Event     // event with too long date range
+	Date  EventDate // date of event with too long range
+	Days  int       // number of days in date range
+	Max   int       // maximum number of days
+}
+
+func (e *DateSpanError) Error() string {
+	return fmt.Sprintf("event %q date range spans %d days, more than %d", e.Event.Title, e.Days, e.Max)
+}
+
+// SplitError is returned when segments of event cell preceding type are ambiguous with WithStrictSplit.
+type SplitError struct {
+	Segments []string // segments preceding type
+}
+
+func (e *SplitError) Error() string {
+	return fmt.Sprintf("%s: %q", ErrAmbiguousSplit, e.Segments)
+}
+
+func (e *SplitError) Unwrap() error {
+	return ErrAmbiguousSplit
+}
+
+// IDCollisionError is returned by AssertUniqueIDs when distinct events have equal ComputeID.
+type IDCollisionError struct {
+	ID     string  // ID shared by events
+	Events []Event // distinct events with ID in order of events
+}
+
+func (e *IDCollisionError) Error() string {
+	titles := make([]string, len(e.Events))
+	for i, event := range e.Events {
+		titles[i] = event.Title
+	}
+	return fmt.Sprintf("%d distinct events %q have equal ID %s", len(e.Events), titles, e.ID)
+}
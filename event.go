@@ -3,13 +3,21 @@
 package scheduleparser
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
 )
 
 // RawEvent contains data, position in pdf file, and initial date to normalize event dates.
@@ -18,6 +26,98 @@ type RawEvent struct {
 	data        string
 	position    pdf.Point
 	initialDate time.Time
+	highlighted bool
+	segments    []string
+	texts       []pdf.Text
+	weekday     string
+	footnotes   []string
+	eventTime   *EventTime
+	labelTimes  []EventTime // times of time labels from row of raw event downwards, see setTimeLabels
+	group       string
+	offset      float64 // X offset of group table from the first one
+	textIndex   int     // index of the first text of raw event in pdf content
+	page        int     // number of pdf page of raw event, or 0 if it is unknown
+	semester    *semester
+	validity    *validity
+	meetingURL  string
+	dateless    bool            // cell ends without dates, see WithDatelessCells
+	config      *rawEventConfig // settings of Parser, or nil for defaults
+}
+
+// rawEventConfig contains settings of Parser used to parse raw events.
+// It is shared by raw events of Parser and isn't modified after options are applied.
+type rawEventConfig struct {
+	times        []EventTime // times of slots configured by WithTimeSlots
+	timeLayouts  []string    // layouts of times written in data, see WithTimeLayouts
+	compactDates bool        // spaces around separators of digits are removed from dates, see WithCompactDates
+	keepBrackets bool        // data after type is sliced as before, see WithKeepBracketsInLocation
+	numerator    Parity      // parity of numerator weeks, see WithNumeratorParity
+}
+
+// defaultRawEventConfig is config of raw events without Parser settings.
+var defaultRawEventConfig rawEventConfig
+
+// settings returns config of raw event or default config if it has none.
+func (raw *RawEvent) settings() *rawEventConfig {
+	if raw.config == nil {
+		return &defaultRawEventConfig
+	}
+	return raw.config
+}
+
+// Data returns text content of raw event.
+func (raw RawEvent) Data() string {
+	return raw.data
+}
+
+// Position returns position of raw event in pdf file.
+func (raw RawEvent) Position() pdf.Point {
+	return raw.position
+}
+
+// Segments returns lines of text that form data of raw event before joining.
+// They are kept only with WithSegments option.
+func (raw RawEvent) Segments() []string {
+	return raw.segments
+}
+
+// Texts returns texts of pdf content that form data of raw event, e.g. runs of glyphs with their coordinates.
+// They are kept only with WithTextRuns option.
+func (raw RawEvent) Texts() []pdf.Text {
+	return raw.texts
+}
+
+// addSegment appends text to the last segment of raw event
+// or starts a new segment if text is on a new line.
+func (raw *RawEvent) addSegment(text string, newLine bool) {
+	if newLine || len(raw.segments) == 0 {
+		raw.segments = append(raw.segments, text)
+		return
+	}
+	raw.segments[len(raw.segments)-1] += text
+}
+
+// isHighlighted reports whether raw event position is inside of non-white fill of its page.
+// Fills that are too thin to be cell background, e.g. table lines, are ignored.
+func (raw *RawEvent) isHighlighted(fills []reader.Fill) bool {
+	const minSize, maxComponent = 2, 0.95
+	for _, fill := range fills {
+		if !samePage(raw.page, fill.Page) {
+			continue
+		}
+		rect := fill.Rect
+		if rect.Max.X-rect.Min.X < minSize || rect.Max.Y-rect.Min.Y < minSize {
+			continue
+		}
+		if fill.Color[0] > maxComponent && fill.Color[1] > maxComponent && fill.Color[2] > maxComponent {
+			continue
+		}
+		pos := raw.position
+		if pos.X >= rect.Min.X && pos.X <= rect.Max.X && pos.Y >= rect.Min.Y && pos.Y <= rect.Max.Y {
+			return true
+		}
+	}
+	return false
 }
 
 // Event is retrieved from RawEvent. It is contained in output json.
@@ -28,63 +128,672 @@ type Event struct {
 	Subgroup string      `json:"subgroup"`
 	Location string      `json:"location"`
 	Dates    []EventDate `json:"dates"`
+	Note     string      `json:"note,omitempty"`
+
+	// CourseCode is course code trimmed from the end of title, e.g. "Б1.О.12".
+	// It is set only with WithTrimTitleSuffixes option.
+	CourseCode string `json:"courseCode,omitempty"`
+
+	// RawTitle is title as it is written in pdf content.
+	// It is set only with WithTrimTitleSuffixes option if title has course code.
+	RawTitle string `json:"rawTitle,omitempty"`
+
+	// RawTeacher is teacher as it is written in pdf content.
+	// It is set only with WithNormalizeTeacher option.
+	RawTeacher string `json:"rawTeacher,omitempty"`
+
+	// RawLocation is location as it is written in pdf content.
+	// It is set only with WithLocationSynonyms option if location is replaced by canonical label.
+	RawLocation string `json:"rawLocation,omitempty"`
+
+	// RawDates are dates as they are written in brackets of pdf content, e.g. "05.09-05.12 к.н.".
+	// They are kept only with WithRawDates option.
+	RawDates []string `json:"rawDates,omitempty"`
+
+	// Subgroups are subgroups of events collapsed into this one by CollapseSubgroups.
+	Subgroups []string `json:"subgroups,omitempty"`
+
+	// SubgroupNumber is number of subgroup, e.g. 1 for "1 подгруппа".
+	// It is zero if subgroup has no number.
+	SubgroupNumber int `json:"subgroupNumber,omitempty"`
+
+	// Group is student group of event, e.g. "ИВТ-101".
+	// It is set by bookmarks of pdf file pointing to pages of groups, or else if header of pdf content has group labels.
+	Group string `json:"group,omitempty"`
+
+	// Weekday is English name of weekday labeling row of event, e.g. "Monday".
+	// It is empty if rows aren't labeled.
+	Weekday string `json:"weekday,omitempty"`
+
+	// MeetingURL is URI of link to online class of event. It is URI of link annotation placed within cell,
+	// detected only with WithMeetingURLs option, or else URL written in cell as "ссылка: <url>".
+	MeetingURL string `json:"meetingURL,omitempty"`
+
+	// Common reports whether cell states that event is common for all subgroups, e.g. "для всех подгрупп".
+	// Subgroup of common event is empty.
+	Common bool `json:"common,omitempty"`
+
+	// Cancelled reports whether cell states that there are no classes, e.g. "занятий нет".
+	// Cancelled event has no type.
+	Cancelled bool `json:"cancelled,omitempty"`
+
+	// Dateless reports whether cell of event has no dates in brackets, e.g. informational cell "Классный час".
+	// Dateless event has no dates and no type. It is formed only with WithDatelessCells(DatelessEvent) option.
+	Dateless bool `json:"dateless,omitempty"`
+
+	// Confidence is score from 0 to 1 of how certainly fields of event are told apart,
+	// e.g. it is lower for ambiguous title and teacher split. It is set only with WithMinConfidence option.
+	Confidence float64 `json:"confidence,omitempty"`
+
+	// Highlighted reports whether cell of event has colored background.
+	// It is detected only with WithHighlight option.
+	Highlighted bool `json:"highlighted,omitempty"`
+
+	// SourceFile is name of pdf file event is parsed from.
+	SourceFile string `json:"-"`
 }
 
-// getRawEvents takes slice of pdf.Text, forms slice of RawEvent and returns it.
-func getRawEvents(texts []pdf.Text, initialDate time.Time) []RawEvent {
-	rawEvents := make([]RawEvent, 0)
-	var (
-		data     string
-		position pdf.Point
-	)
+// Clone returns deep copy of event that shares no slices with it.
+func (event Event) Clone() Event {
+	clone := event
+	if event.Dates != nil {
+		clone.Dates = make([]EventDate, len(event.Dates))
+		for i := range event.Dates {
+			clone.Dates[i] = event.Dates[i].Clone()
+		}
+	}
+	if event.RawDates != nil {
+		clone.RawDates = append([]string(nil), event.RawDates...)
+	}
+	if event.Subgroups != nil {
+		clone.Subgroups = append([]string(nil), event.Subgroups...)
+	}
+	return clone
+}
+
+// legacyEvent is Event with dates encoded in legacy flat shape.
+type legacyEvent struct {
+	Event
+	Dates       []legacyEventDate `json:"dates"`
+	Occurrences []string          `json:"occurrences,omitempty"`
+}
+
+// occurrencesEvent is Event with civil dates of its occurrences.
+type occurrencesEvent struct {
+	Event
+	Occurrences []string `json:"occurrences"`
+}
+
+// newLegacyEvents converts slice of Event to slice of legacyEvent.
+func newLegacyEvents(events []Event) []legacyEvent {
+	legacyEvents := make([]legacyEvent, len(events))
+	for i, event := range events {
+		dates := make([]legacyEventDate, len(event.Dates))
+		for j, date := range event.Dates {
+			dates[j] = legacyEventDate(date)
+		}
+		legacyEvents[i] = legacyEvent{Event: event, Dates: dates}
+	}
+	return legacyEvents
+}
+
+// eventTypes maps type keywords in pdf content to event types.
+var eventTypes = map[string]string{
+	"лекции":  "lecture",
+	"семинар": "seminar",
+	"лабораторные занятия": "lab",
+}
+
+// sortedKeywords returns keywords of types sorted by length in descending order and then alphabetically.
+func sortedKeywords(types map[string]string) []string {
+	keywords := make([]string, 0, len(types))
+	for keyword := range types {
+		keywords = append(keywords, keyword)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if li, lj := utf8.RuneCountInString(keywords[i]), utf8.RuneCountInString(keywords[j]); li != lj {
+			return li > lj
+		}
+		return keywords[i] < keywords[j]
+	})
+	return keywords
+}
+
+// newTypeRegexp compiles regexp matching any keyword of types followed by dot regardless of case,
+// e.g. "Лекции." and "СЕМИНАР.". Keywords are sorted by length in descending order so that the longest keyword wins
+// when several keywords match at the same position.
+func newTypeRegexp(types map[string]string) *regexp.Regexp {
+	keywords := sortedKeywords(types)
+	for i, keyword := range keywords {
+		keywords[i] = regexp.QuoteMeta(keyword)
+	}
+	return regexp.MustCompile(fmt.Sprintf(`(?i)(%s)\.`, strings.Join(keywords, "|")))
+}
+
+// typeRegexps caches regexps compiled by newTypeRegexp by joined sorted keywords.
+var typeRegexps sync.Map
+
+// typeRegexp returns regexp compiled by newTypeRegexp for types.
+// Regexp is compiled once for each set of keywords, and it is safe for concurrent use.
+func typeRegexp(types map[string]string) *regexp.Regexp {
+	key := strings.Join(sortedKeywords(types), "\x00")
+	if cached, ok := typeRegexps.Load(key); ok {
+		return cached.(*regexp.Regexp)
+	}
+	cached, _ := typeRegexps.LoadOrStore(key, newTypeRegexp(types))
+	return cached.(*regexp.Regexp)
+}
+
+// joinLines appends text starting new line to data.
+// Lines are separated by space unless data ends with hyphen following letter:
+// hyphen of word wrapped before lowercase letter is removed, e.g. "Математи-" and "ка" become "Математика",
+// and hyphen of compound word wrapped before uppercase letter is kept, e.g. "Северо-Западный".
+func joinLines(data, text string) string {
+	prefix := strings.TrimSuffix(data, "-")
+	last, _ := utf8.DecodeLastRuneInString(prefix)
+	next, _ := utf8.DecodeRuneInString(text)
+	if prefix == data || !unicode.IsLetter(last) || !unicode.IsLetter(next) {
+		return data + " " + text
+	}
+	if unicode.IsUpper(next) {
+		return data + text
+	}
+	return prefix + text
+}
+
+// rawEventScanner forms raw events from texts passed to scan in one or several parts,
+// e.g. page by page, so that cell beginning in one part may end in the next one.
+type rawEventScanner struct {
+	p           *Parser
+	initialDate time.Time
+	rawEvents   []RawEvent // raw events not taken yet
+	count       int        // number of raw events formed
+
+	data     string
+	position pdf.Point
+	start    int          // index of the first text of current raw event
+	scanned  int          // number of texts scanned
+	current  RawEvent     // segments and texts of current raw event
+	lastY    float64      // Y coordinate of the last text added to segments
+	prevY    float64      // Y coordinate of the previous text
+	prevEnd  float64      // X coordinate of the end of the previous text
+	closed   bool         // last raw event is closed by dates and may be followed by note
+	depth    int          // depth of parentheses in note of last raw event
+	bare     bool         // last raw event is followed by note without parentheses on line of its dates
+	pages    map[int]bool // indexes of texts starting pdf pages
+	leading  bool         // current raw event starts with dates and is closed by dates of the next one
+}
+
+// trailingURLRegexp matches http(s) URL at the end of data.
+var trailingURLRegexp = regexp.MustCompile(`(?i)https?://\S*$`)
+
+// closesURLBracket reports whether "]" at the end of data closes bracket opened inside http(s) URL,
+// e.g. in query string "?room[id]=5", rather than dates of cell.
+func closesURLBracket(data string) bool {
+	url := trailingURLRegexp.FindString(data)
+	return url != "" && strings.Count(url, "[") >= strings.Count(url, "]")
+}
+
+// appendRawEvent forms raw event from current data.
+func (s *rawEventScanner) appendRawEvent() error {
+	if s.p.maxEvents > 0 && s.count == s.p.maxEvents {
+		return fmt.Errorf("%w: more than %d", ErrTooManyEvents, s.p.maxEvents)
+	}
+	s.rawEvents = append(s.rawEvents, RawEvent{data: s.data, position: s.position, initialDate: s.initialDate, segments: s.current.segments, texts: s.current.texts, textIndex: s.start, config: &s.p.config})
+	s.count++
+	s.data = ""
+	s.current.segments, s.current.texts = nil, nil
+	return nil
+}
+
+// scan forms raw events from texts following texts of previous calls.
+// Texts of each call after the first one are taken to start new pdf page.
+func (s *rawEventScanner) scan(texts []pdf.Text) error {
+	p := s.p
 	for i, text := range texts {
-		if text.Y < 521 && text.X > 42 {
-			if data == "" {
-				position = pdf.Point{X: text.X, Y: text.Y}
-			} else if texts[i].Y != texts[i-1].Y {
-				data += " "
+		newPage := s.pages[s.scanned] || (i == 0 && s.scanned > 0)
+		s.scanned++
+		newLine := text.Y != s.prevY
+		gap := text.X - s.prevEnd
+		s.prevY, s.prevEnd = text.Y, text.X+text.W
+		if text.Y < tableTop && (p.footnotesTop <= 0 || text.Y >= p.footnotesTop) && text.X > tableLeft {
+			if s.depth > 0 {
+				last := &s.rawEvents[len(s.rawEvents)-1]
+				if newLine {
+					last.data = joinLines(last.data, text.S)
+				} else {
+					last.data += text.S
+				}
+				if p.segments {
+					last.addSegment(text.S, text.Y != s.lastY)
+					s.lastY = text.Y
+				}
+				if p.textRuns {
+					last.texts = append(last.texts, text)
+				}
+				if p.maxCellLength > 0 && len(last.data) > p.maxCellLength {
+					return fmt.Errorf("%w: events[%d] exceeds %d bytes", ErrCellTooLong, s.count-1, p.maxCellLength)
+				}
+				switch text.S {
+				case "(":
+					s.depth++
+				case ")":
+					s.depth--
+				}
+				continue
+			}
+			continuesNote := !newLine && !newPage && math.Abs(gap) <= maxNoteGap
+			if s.bare {
+				if continuesNote {
+					s.appendNote(text, "")
+					continue
+				}
+				s.bare = false
+			}
+			if s.closed {
+				if strings.TrimSpace(text.S) == "" {
+					continue
+				}
+				s.closed = false
+				if continuesNote && text.S != "(" {
+					s.appendNote(text, " ")
+					s.bare = true
+					continue
+				}
+				if text.S == "(" {
+					last := &s.rawEvents[len(s.rawEvents)-1]
+					last.data += " ("
+					if p.segments {
+						last.addSegment(text.S, text.Y != s.lastY)
+						s.lastY = text.Y
+					}
+					if p.textRuns {
+						last.texts = append(last.texts, text)
+					}
+					s.depth = 1
+					continue
+				}
+			}
+
+			if s.leading && text.S == "[" {
+				if err := s.appendRawEvent(); err != nil {
+					return err
+				}
+				s.leading = false
+			}
+			if newLine && s.data != "" && !s.leading && p.datelessCells != DatelessMerge && s.endsDateless(text) {
+				if err := s.appendDateless(); err != nil {
+					return err
+				}
+			}
+			if s.data == "" {
+				s.position = pdf.Point{X: text.X, Y: text.Y}
+				s.start = s.scanned - 1
+				s.data = text.S
+			} else if newLine {
+				s.data = joinLines(s.data, text.S)
+			} else {
+				s.data += text.S
+			}
+			if p.segments {
+				s.current.addSegment(text.S, text.Y != s.lastY)
+				s.lastY = text.Y
+			}
+			if p.textRuns {
+				s.current.texts = append(s.current.texts, text)
+			}
+			if p.maxCellLength > 0 && len(s.data) > p.maxCellLength {
+				return fmt.Errorf("%w: events[%d] exceeds %d bytes", ErrCellTooLong, s.count, p.maxCellLength)
 			}
-			data += text.S
-			if text.S == "]" {
-				rawEvents = append(rawEvents, RawEvent{data, position, initialDate})
-				data = ""
+			if text.S == "]" && !s.leading && !closesURLBracket(s.data) {
+				// Cell starting with dates lasts until dates of the next cell.
+				if strings.HasPrefix(strings.TrimSpace(s.data), "[") {
+					s.leading = true
+					continue
+				}
+				if err := s.appendRawEvent(); err != nil {
+					return err
+				}
+				s.closed = true
 			}
 		}
 	}
+	return nil
+}
+
+// close forms raw event from cell starting with dates that is left after the last call of scan,
+// or from cell without dates unless they are merged.
+func (s *rawEventScanner) close() error {
+	if strings.TrimSpace(s.data) == "" {
+		return nil
+	}
+	if s.leading {
+		return s.appendRawEvent()
+	}
+	if s.p.datelessCells != DatelessMerge && !strings.Contains(s.data, "[") {
+		return s.appendDateless()
+	}
+	return nil
+}
+
+// maxNoteGap is maximum horizontal distance between end of text and start of the next text
+// of note without parentheses following dates of cell. Farther text, e.g. the next cell of the same row
+// or the first cell of the next page, doesn't continue note.
+const maxNoteGap = 5
+
+// appendNote appends text of note following dates to data of the last raw event after sep.
+func (s *rawEventScanner) appendNote(text pdf.Text, sep string) {
+	last := &s.rawEvents[len(s.rawEvents)-1]
+	last.data += sep + text.S
+	if s.p.segments {
+		last.addSegment(text.S, text.Y != s.lastY)
+		s.lastY = text.Y
+	}
+	if s.p.textRuns {
+		last.texts = append(last.texts, text)
+	}
+}
+
+// take returns formed raw events and removes them from scanner.
+// The last raw event is kept if note following its dates may continue in texts of the next call of scan.
+func (s *rawEventScanner) take() []RawEvent {
+	n := len(s.rawEvents)
+	if n > 0 && (s.closed || s.depth > 0 || s.bare) {
+		n--
+	}
+	rawEvents := s.rawEvents[:n:n]
+	s.rawEvents = append([]RawEvent(nil), s.rawEvents[n:]...)
 	return rawEvents
 }
 
+// getRawEvents takes slice of pdf.Text and starts of its pdf pages, forms slice of RawEvent and returns it.
+// Parenthesized note following dates of event is appended to its data, as well as note without parentheses
+// continuing line of dates up to text farther than maxNoteGap. Note without parentheses ends at the end of line
+// or page, so note wrapped to the next lines is expected to be parenthesized.
+// It returns error if number of events or length of cell exceeds limits of Parser.
+// Texts in footnotes region of Parser are skipped.
+// Cell may start with dates instead, and then it lasts until dates of the next cell,
+// so all cells of such pdf content are expected to start with dates.
+func (p *Parser) getRawEvents(texts []pdf.Text, pageStarts []reader.PageStart, initialDate time.Time) ([]RawEvent, error) {
+	s := &rawEventScanner{p: p, initialDate: initialDate, rawEvents: make([]RawEvent, 0), pages: make(map[int]bool)}
+	for _, start := range pageStarts {
+		s.pages[start.Index] = true
+	}
+	if err := s.scan(texts); err != nil {
+		return nil, err
+	}
+	if err := s.close(); err != nil {
+		return nil, err
+	}
+	return s.rawEvents, nil
+}
+
+// subgroupRegexp matches subgroup notations such as
+// "(1 подгруппа)", "подгр.1" and "1 п/г".
+var subgroupRegexp = regexp.MustCompile(`\(?(?:(\d+)\s*(?:подгруппа|подгр\.?|п/г)|(?:подгруппа|подгр\.?|п/г)\s*(\d+))\)?`)
+
+// parseSubgroupNumber returns number of subgroup matched by subgroupRegexp.
+func parseSubgroupNumber(s string, indexes []int) int {
+	for i := 2; i+1 < len(indexes); i += 2 {
+		if indexes[i] >= 0 {
+			number, _ := strconv.Atoi(s[indexes[i]:indexes[i+1]])
+			return number
+		}
+	}
+	return 0
+}
+
+// cutSubgroup returns data preceding type without subgroup notation written in it, e.g. "Физика." of "Физика 1 п/г.",
+// and subgroup with its number. Separator following subgroup is removed with it, and trailing "." is kept.
+func cutSubgroup(beforeType string) (string, string, int) {
+	indexes := subgroupRegexp.FindStringSubmatchIndex(beforeType)
+	if indexes == nil {
+		return beforeType, "", 0
+	}
+	subgroup := strings.TrimSpace(strings.Trim(beforeType[indexes[0]:indexes[1]], "()"))
+	number := parseSubgroupNumber(beforeType, indexes)
+	before, after := strings.TrimRight(beforeType[:indexes[0]], " "), beforeType[indexes[1]:]
+	if strings.HasSuffix(before, ".") {
+		after = strings.TrimPrefix(after, ".")
+	}
+	rest := before + after
+	if (after == "" || strings.HasSuffix(beforeType, ".")) && !strings.HasSuffix(rest, ".") {
+		rest += "."
+	}
+	return rest, subgroup, number
+}
+
+// isParenthesized reports whether s is enclosed in parentheses, ignoring surrounding whitespace.
+func isParenthesized(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")")
+}
+
+// joinLocation joins data before and after subgroup into location,
+// dropping periods separating them from subgroup.
+func joinLocation(before, after string) string {
+	parts := make([]string, 0, 2)
+	if before = strings.TrimSuffix(strings.TrimSpace(before), "."); before != "" {
+		parts = append(parts, before)
+	}
+	if after = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(after), ".")); after != "" {
+		parts = append(parts, after)
+	}
+	return strings.Join(parts, ". ")
+}
+
+// cancelledRegexp matches phrase stating that there are no classes at the end of text preceding dates of cell.
+var cancelledRegexp = regexp.MustCompile(`(?i)(?:занятий\s+нет|нет\s+занятий)\.?$`)
+
+// beforeDates returns text of cell preceding its dates, or whole text if cell has no dates.
+func beforeDates(text string) string {
+	if i := strings.Index(text, "["); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text)
+}
+
+// IsCancelled reports whether text of cell states that there are no classes,
+// e.g. "занятий нет" or "Title. Нет занятий. [19.09]". Only text preceding dates is checked,
+// so note following dates, e.g. "(12.10 занятий нет)", doesn't cancel the class.
+func IsCancelled(text string) bool {
+	return cancelledRegexp.MatchString(beforeDates(text))
+}
+
+// parseCancelledEvent parses *RawEvent of cell without classes and returns *Event with Cancelled flag.
+// Text preceding cancellation phrase becomes title, and dates are parsed if cell has them.
+func parseCancelledEvent(raw *RawEvent) (*Event, error) {
+	before := beforeDates(raw.data)
+	indexes := cancelledRegexp.FindStringIndex(before)
+	event := &Event{
+		Title:     strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(before[:indexes[0]]), ".")),
+		Weekday:   raw.weekday,
+		Group:     raw.group,
+		Cancelled: true,
+		Dateless:  raw.dateless,
+
+		Highlighted: raw.highlighted,
+	}
+	if datesRegexp.MatchString(raw.data) {
+		dates, _, err := parseDates(raw, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parseDates error: %w", err)
+		}
+		event.Dates = dates
+	}
+	return event, nil
+}
+
+// practiceType is type of practice block events.
+const practiceType = "practice"
+
+// practiceRegexp matches title of practice block, e.g. "Учебная практика".
+// Practice block is formed only from cell without type.
+var practiceRegexp = regexp.MustCompile(`(?i)^[^.\[]*практика`)
+
+// initialRegexp matches initial at the end of segment, e.g. "Teacher T.T".
+var initialRegexp = regexp.MustCompile(`(?:^|[\s.])\p{Lu}$`)
+
+// practiceTime is time of every day of practice block.
+var practiceTime = EventTime{Clock{0, 0}, Clock{23, 59}}
+
+// parsePracticeEvent parses *RawEvent of practice block and returns *Event of practice type.
+// Practice block occupies continuous date ranges, e.g. "[05.09-18.09]", instead of weekly cell times,
+// so its dates have FrequencyContinuous and last whole days.
+// Segments following title are teacher and location.
+func parsePracticeEvent(raw *RawEvent) (*Event, error) {
+	datesIndexes := datesRegexp.FindAllStringIndex(raw.data, -1)
+	if datesIndexes == nil {
+		return nil, fmt.Errorf("%w: practice dates are not found", ErrMalformedCell)
+	}
+	datesIndex, datesEnd := datesIndexes[len(datesIndexes)-1][0], datesIndexes[len(datesIndexes)-1][1]
+
+	dates := make([]EventDate, 0)
+	datesString := dashReplacer.Replace(strings.Trim(raw.data[datesIndex:datesEnd], "[]"))
+	for _, rangeString := range strings.Split(datesString, ", ") {
+		bounds := strings.Split(strings.TrimSpace(rangeString), "-")
+		if len(bounds) > 2 {
+			return nil, fmt.Errorf("%w: incorrect date range %q", ErrDateParse, rangeString)
+		}
+		for _, bound := range bounds {
+			if !isDate(bound) {
+				return nil, fmt.Errorf("%w: incorrect date range %q", ErrDateParse, rangeString)
+			}
+		}
+		date := NewEventDate(bounds[0], bounds[len(bounds)-1], &practiceTime, FrequencyContinuous)
+		date.normalize(raw.initialDate)
+		dates = append(dates, *date)
+	}
+
+	event := &Event{
+		Type:       practiceType,
+		Dates:      dates,
+		Weekday:    raw.weekday,
+		Group:      raw.group,
+		MeetingURL: raw.meetingURL,
+
+		Highlighted: raw.highlighted,
+	}
+	segments := strings.Split(strings.TrimSuffix(strings.TrimSpace(raw.data[:datesIndex]), "."), ". ")
+	for i := range segments[:len(segments)-1] {
+		// Period of initials is also separator of segments.
+		if initialRegexp.MatchString(segments[i]) {
+			segments[i] += "."
+		}
+	}
+	event.Title = strings.TrimSpace(segments[0])
+	if len(segments) > 1 {
+		event.Teacher = strings.TrimSpace(segments[1])
+	}
+	if len(segments) > 2 {
+		event.Location = strings.TrimSpace(strings.Join(segments[2:], ". "))
+	}
+	return event, nil
+}
+
+// leadingDatesRegexp matches bracket block of dates preceding title of event.
+var leadingDatesRegexp = regexp.MustCompile(`^\s*(\[[^\[\]]+\])\s*`)
+
+// commonRegexp matches marker of event common for all subgroups with following separator.
+var commonRegexp = regexp.MustCompile(`(?i)\(?для\s+всех\s+подгрупп\)?\.?\s*`)
+
+// afterType returns data of raw event between type ending at typeEnd and dates starting at datesStart
+// without surrounding spaces and trailing dot, e.g. "Location" of "лекции. Location. [05.09]".
+// Raw event of Parser with WithKeepBracketsInLocation(true) has data sliced as it was before,
+// i.e. without one character after type and two characters before dates.
+func afterType(raw *RawEvent, typeEnd, datesStart int) string {
+	if raw.settings().keepBrackets && typeEnd+1 <= datesStart-2 {
+		return raw.data[typeEnd+1 : datesStart-2]
+	}
+	if typeEnd >= datesStart {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimSpace(raw.data[typeEnd:datesStart]), ".")
+}
+
+// trailingTeacherRegexp matches teacher with two initials ending data after type, e.g. "Location. Teacher T.T".
+// Capitalized word before initials is surname unless it is location keyword matched by locationKeywordRegexp.
+var trailingTeacherRegexp = regexp.MustCompile(`(?:^|\.\s+)(\p{Lu}\p{Ll}+(?:-\p{Lu}\p{Ll}+)?\s+\p{Lu}\.\s*\p{Lu}\.?)$`)
+
+// locationKeywordRegexp matches location keyword starting match of trailingTeacherRegexp, e.g. "Корпус А.Б.".
+var locationKeywordRegexp = regexp.MustCompile(`(?i)^(?:корп(?:ус)?|ауд(?:итория)?|каб(?:инет)?)\s`)
+
 // parseEvent parses *RawEvent and returns *Event.
 func parseEvent(raw *RawEvent) (*Event, error) {
+	// Remove marker of event common for all subgroups from data.
+	var eventCommon bool
+	if commonRegexp.MatchString(raw.data) {
+		common := *raw
+		common.data = commonRegexp.ReplaceAllString(raw.data, "")
+		raw, eventCommon = &common, true
+	}
+
+	// Extract meeting link written as text from data.
+	if data, link, ok := extractTextLink(raw.data); ok {
+		linked := *raw
+		linked.data = data
+		if linked.meetingURL == "" {
+			linked.meetingURL = link
+		}
+		raw = &linked
+	}
+
+	// Parse time preceding title from data.
+	if eventTime, data, ok := parseLeadingTime(raw.data, raw.settings().timeLayouts); ok {
+		leading := *raw
+		leading.data, leading.eventTime = data, eventTime
+		raw = &leading
+	}
+
+	// Move dates preceding title to the end of data.
+	if indexes := leadingDatesRegexp.FindStringSubmatchIndex(raw.data); indexes != nil {
+		rest := strings.TrimSpace(raw.data[indexes[1]:])
+		if !strings.HasSuffix(rest, ".") {
+			rest += "."
+		}
+		leading := *raw
+		leading.data = rest + " " + raw.data[indexes[2]:indexes[3]]
+		raw = &leading
+	}
+
+	if IsCancelled(raw.data) {
+		return parseCancelledEvent(raw)
+	}
+	if raw.dateless {
+		return parseDatelessEvent(raw), nil
+	}
+
 	// Parse type from data.
-	const (
-		lecture = "лекции"
-		seminar = "семинар"
-		lab     = "лабораторные занятия"
-	)
-	typeRegexp := regexp.MustCompile(fmt.Sprintf(`(%s|%s|%s)\.`, lecture, seminar, lab))
-	typeIndexes := typeRegexp.FindStringIndex(raw.data)
-	if typeIndexes == nil {
-		return nil, errors.New("schedule event type is not found")
+	typeIndexes := typeRegexp(eventTypes).FindStringIndex(raw.data)
+	// Cell of practice block has no type, so title containing "практика" doesn't make cell of class practice block.
+	if typeIndexes == nil && practiceRegexp.MatchString(raw.data) {
+		return parsePracticeEvent(raw)
 	}
-	eventTypes := map[string]string{
-		lecture: "lecture",
-		seminar: "seminar",
-		lab:     "lab",
+	if typeIndexes == nil {
+		return nil, ErrTypeNotFound
 	}
-	eventType := eventTypes[raw.data[typeIndexes[0]:typeIndexes[1]-1]]
+	eventType := eventTypes[strings.ToLower(raw.data[typeIndexes[0]:typeIndexes[1]-1])]
 
 	// Parse title and teacher from data.
 	var eventTitle, eventTeacher string
 
-	stringsBeforeType := strings.Split(raw.data[:typeIndexes[0]-1], ". ")
+	if strings.TrimSpace(raw.data[:typeIndexes[0]]) == "" {
+		return nil, fmt.Errorf("%w: title is not found before type %q", ErrMalformedCell, raw.data[typeIndexes[0]:typeIndexes[1]-1])
+	}
+	beforeType, beforeSubgroup, beforeSubgroupNumber := cutSubgroup(raw.data[:typeIndexes[0]-1])
+	if strings.TrimSpace(strings.TrimSuffix(beforeType, ".")) == "" {
+		return nil, fmt.Errorf("%w: title is not found before subgroup %q", ErrMalformedCell, beforeSubgroup)
+	}
+	stringsBeforeType := strings.Split(beforeType, ". ")
 	if len(stringsBeforeType) == 1 {
 		eventTitle = stringsBeforeType[0]
 		eventTitle = eventTitle[:len(eventTitle)-1]
 	} else {
 		eventTitle = stringsBeforeType[0]
-		eventTeacher = stringsBeforeType[1]
+		// Spaced initials, e.g. "Иванов И. И.", are split into several strings.
+		eventTeacher = strings.TrimSpace(strings.Join(stringsBeforeType[1:], ". "))
 	}
+	eventTitle = strings.TrimSpace(eventTitle)
 
 	// Parse dates from data and position.
 	var (
@@ -101,36 +810,220 @@ func parseEvent(raw *RawEvent) (*Event, error) {
 		return nil, fmt.Errorf("parseDates error: %w", err)
 	}
 
-	// Parse subgroup and location from data.
-	var eventSubgroup, eventLocation string
-
-	stringsAfterType := strings.Split(raw.data[typeIndexes[1]+1:datesStartIndex-2], ". ")
-	if len(stringsAfterType) == 2 {
-		eventSubgroup = strings.Trim(stringsAfterType[0], "()")
-		eventLocation = stringsAfterType[1]
+	// Parse subgroup, location and extra note from data.
+	var (
+		eventSubgroup, eventLocation, extraNote string
+		eventSubgroupNumber                     int
+	)
+	dataAfterType := afterType(raw, typeIndexes[1], datesStartIndex)
+	// Teacher follows location in cells without teacher before type, e.g. "Title. лекции. Location. Teacher T.T.".
+	if indexes := trailingTeacherRegexp.FindStringSubmatchIndex(dataAfterType); indexes != nil && eventTeacher == "" &&
+		!locationKeywordRegexp.MatchString(dataAfterType[indexes[2]:indexes[3]]) {
+		eventTeacher = strings.TrimSuffix(dataAfterType[indexes[2]:indexes[3]], ".") + "."
+		dataAfterType = dataAfterType[:indexes[0]]
+	}
+	if indexes := subgroupRegexp.FindStringSubmatchIndex(dataAfterType); indexes != nil {
+		eventSubgroup = strings.TrimSpace(strings.Trim(dataAfterType[indexes[0]:indexes[1]], "()"))
+		eventSubgroupNumber = parseSubgroupNumber(dataAfterType, indexes)
+		eventLocation = joinLocation(dataAfterType[:indexes[0]], dataAfterType[indexes[1]:])
+	} else if stringsAfterType := strings.Split(dataAfterType, ". "); len(stringsAfterType) >= 2 {
+		// Subgroup precedes location unless only location is followed by parenthesized segment,
+		// e.g. "Location. (Subgroup)".
+		subgroupIndex := 0
+		if !isParenthesized(stringsAfterType[0]) && isParenthesized(stringsAfterType[1]) {
+			subgroupIndex = 1
+		}
+		eventSubgroup = strings.TrimSpace(strings.Trim(strings.TrimSpace(stringsAfterType[subgroupIndex]), "()"))
+		eventSubgroupNumber, _ = strconv.Atoi(eventSubgroup)
+		eventLocation = strings.TrimSpace(stringsAfterType[1-subgroupIndex])
+		// Segments following location, e.g. "(1). 101. кафедра", are kept as note.
+		extraNote = strings.TrimSpace(strings.Join(stringsAfterType[2:], ". "))
 	} else {
-		eventLocation = stringsAfterType[0]
+		eventLocation = strings.TrimSpace(stringsAfterType[0])
+	}
+	if eventSubgroup == "" {
+		eventSubgroup, eventSubgroupNumber = beforeSubgroup, beforeSubgroupNumber
+	}
+
+	// Parse note following dates from data.
+	eventNote := raw.data[strings.LastIndex(raw.data, "]")+1:]
+	eventNote = strings.TrimSpace(eventNote)
+	if strings.HasPrefix(eventNote, "(") && strings.HasSuffix(eventNote, ")") {
+		eventNote = strings.TrimSpace(eventNote[1 : len(eventNote)-1])
+	}
+	notes := make([]string, 0, 2+len(raw.footnotes))
+	for _, note := range append([]string{extraNote, eventNote}, raw.footnotes...) {
+		if note != "" {
+			notes = append(notes, note)
+		}
 	}
+	eventNote = strings.Join(notes, "; ")
 
 	return &Event{
-		eventTitle,
-		eventTeacher,
-		eventType,
-		eventSubgroup,
-		eventLocation,
-		eventDates,
+		Title:    eventTitle,
+		Teacher:  eventTeacher,
+		Type:     eventType,
+		Subgroup: eventSubgroup,
+		Location: eventLocation,
+
+		SubgroupNumber: eventSubgroupNumber,
+		Dates:          eventDates,
+		Note:           eventNote,
+		Weekday:        raw.weekday,
+		Common:         eventCommon,
+		MeetingURL:     raw.meetingURL,
+		Group:          raw.group,
+
+		Highlighted: raw.highlighted,
 	}, nil
 }
 
 // parseEvents takes slice of RawEvent, forms slice of Event and returns it.
-func parseEvents(rawEvents []RawEvent) ([]Event, error) {
-	events := make([]Event, 0)
-	for i, rawEvent := range rawEvents {
-		event, err := parseEvent(&rawEvent)
+// If Parser has error handler, failed raw events are passed to it and skipped.
+// Events excluded by WithMinConfidence are skipped without error handler as well.
+func (p *Parser) parseEvents(rawEvents []RawEvent) ([]Event, error) {
+	return p.parseEventsContext(context.Background(), rawEvents)
+}
+
+// parseEventsContext is parseEvents that stops with error of ctx when ctx is done.
+// Error of ctx isn't passed to error handler of Parser.
+func (p *Parser) parseEventsContext(ctx context.Context, rawEvents []RawEvent) ([]Event, error) {
+	events := make([]Event, 0, len(rawEvents))
+	for i := range rawEvents {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("parse events[%d]: %w", i, err)
+		}
+		rawEvent := &rawEvents[i]
+		event, err := p.parseRawEvent(rawEvent)
+		if errors.Is(err, ErrOutOfValidity) {
+			if p.errorHandler != nil {
+				p.errorHandler(i, *rawEvent, err)
+			}
+			events = append(events, *event)
+			continue
+		}
 		if err != nil {
+			if p.errorHandler != nil {
+				p.errorHandler(i, *rawEvent, err)
+				continue
+			}
+			if errors.Is(err, ErrLowConfidence) {
+				continue
+			}
 			return nil, fmt.Errorf("parse events[%d]: %w", i, err)
 		}
 		events = append(events, *event)
 	}
 	return events, nil
 }
+
+// parseRawEvent parses raw event using parseEvent, post-processes *Event according to options of Parser
+// and checks it against limits of Parser. Event is returned along with error wrapping ErrOutOfValidity.
+func (p *Parser) parseRawEvent(raw *RawEvent) (*Event, error) {
+	event, err := parseEvent(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkSplit(event); err != nil {
+		return nil, err
+	}
+	if p.minConfidence > 0 {
+		if event.Confidence = scoreEvent(raw, event); event.Confidence < p.minConfidence {
+			return nil, fmt.Errorf("%w: event %q has confidence %.2f, less than %.2f", ErrLowConfidence, event.Title, event.Confidence, p.minConfidence)
+		}
+	}
+	if p.rawDates {
+		event.RawDates = rawDates(raw.data)
+	}
+	if p.config.times != nil {
+		for i := range event.Dates {
+			event.Dates[i].PairNumber = pairNumber(event.Dates[i].Start, p.config.times)
+		}
+	}
+	if p.trimTitleSuffixes {
+		if title, code := splitCourseCode(event.Title); code != "" {
+			event.RawTitle, event.Title, event.CourseCode = event.Title, title, code
+		}
+	}
+	if p.normalizeTeacher {
+		event.RawTeacher, event.Teacher = event.Teacher, NormalizeTeacher(event.Teacher)
+	}
+	if label, ok := p.locationSynonyms[synonymKey(event.Location)]; ok {
+		event.RawLocation, event.Location = event.Location, label
+	}
+	if p.yoDictionary != nil {
+		for _, field := range []*string{&event.Title, &event.Teacher, &event.Location, &event.Note} {
+			*field = restoreYo(*field, p.yoDictionary)
+		}
+	}
+	normalizeFields(event, p.fieldNormalizers)
+	if err := p.checkDateSpan(event); err != nil {
+		return nil, err
+	}
+	if err := p.checkOccurrences(event); err != nil {
+		return nil, err
+	}
+	if err := checkValidity(event, raw.validity); err != nil {
+		return event, err
+	}
+	return event, nil
+}
+
+// checkValidity returns error wrapping ErrOutOfValidity if dates of event are outside of validity period of schedule.
+// Event is valid if schedule has no validity period.
+func checkValidity(event *Event, validity *validity) error {
+	if validity == nil {
+		return nil
+	}
+	for _, date := range event.Dates {
+		if !validity.contains(date.Start) || !validity.contains(date.End) {
+			return fmt.Errorf("%w: date %s-%s is out of validity period %s-%s", ErrOutOfValidity,
+				date.Start.Format(dateFormat), date.End.Format(dateFormat), validity.from.Format(dateFormat), validity.to.Format(dateFormat))
+		}
+	}
+	return nil
+}
+
+// spacedInitialRegexp matches initial separated from preceding one by space, e.g. "И." of "Иванов И. И.".
+var spacedInitialRegexp = regexp.MustCompile(`^\p{Lu}\.?$`)
+
+// checkSplit returns SplitError if Parser is strict and teacher of event consists of several segments
+// that are not spaced initials, so title and teacher might have been split incorrectly.
+func (p *Parser) checkSplit(event *Event) error {
+	if !p.strictSplit {
+		return nil
+	}
+	if isAmbiguousSplit(event.Teacher) {
+		return &SplitError{Segments: append([]string{event.Title}, strings.Split(event.Teacher, ". ")...)}
+	}
+	return nil
+}
+
+// isAmbiguousSplit reports whether teacher consists of several segments that are not spaced initials,
+// e.g. "Subtitle. Teacher T.T.", so it may contain part of title.
+func isAmbiguousSplit(teacher string) bool {
+	for _, segment := range strings.Split(teacher, ". ")[1:] {
+		if !spacedInitialRegexp.MatchString(segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDateSpan returns DateSpanError if any date range of event is longer than limit of Parser.
+func (p *Parser) checkDateSpan(event *Event) error {
+	if p.maxDateSpan <= 0 {
+		return nil
+	}
+	for _, date := range event.Dates {
+		if span := days(date.End) - days(date.Start); span > p.maxDateSpan {
+			return &DateSpanError{Event: *event, Date: date, Days: span, Max: p.maxDateSpan}
+		}
+	}
+	return nil
+}
+
+// checkOccurrences returns error wrapping ErrTooManyOccurrences if event occurs more times than allowed by Parser.
+func (p *Parser) checkOccurrences(event *Event) error {
+	return checkOccurrenceCount(event, p.maxOccurrences)
+}
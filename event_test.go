@@ -3,11 +3,15 @@
 package scheduleparser
 
 import (
+	"errors"
 	"reflect"
+	"regexp"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
 )
 
 func Test_parseEvent(t *testing.T) {
@@ -26,19 +30,193 @@ func Test_parseEvent(t *testing.T) {
 	}{
 		{
 			"WithoutSubgroup",
-			args{&RawEvent{"Title. Teacher T.T. лекции. Location. [05.09-05.12 к.н.]", pdf.Point{X: 46, Y: 0}, initialDate}},
-			&Event{"Title", "Teacher T.T.", "lecture", "", "Location", []EventDate{{time.Date(2000, 9, 5, 8, 30, 0, 0, loc), time.Date(2000, 12, 5, 10, 10, 0, 0, loc), "every"}}},
+			args{&RawEvent{data: "Title. Teacher T.T. лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lecture", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every"}}},
+			false,
+		},
+		{
+			"TeacherAfterLocation",
+			args{&RawEvent{data: "Title. лекции. Location. Teacher T.T. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lecture", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every"}}},
+			false,
+		},
+		{
+			"LocationLikeTeacher",
+			args{&RawEvent{data: "Title. лекции. Корпус А.Б. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}},
+			&Event{Title: "Title", Type: "lecture", Location: "Корпус А.Б", Dates: []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every"}}},
+			false,
+		},
+		{
+			"SpacedTeacherAfterSubgroup",
+			args{&RawEvent{data: "Title. лабораторные занятия. (Subgroup). Location. Teacher T. T. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T. T.", Type: "lab", Subgroup: "Subgroup", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
 			false,
 		},
 		{
 			"WithSubgroup",
-			args{&RawEvent{"Title. Teacher T.T. лабораторные занятия. (Subgroup). Location. [19.09-17.10 ч.н.]", pdf.Point{X: 233, Y: 513}, initialDate}},
-			&Event{"Title", "Teacher T.T.", "lab", "Subgroup", "Location", []EventDate{{time.Date(2000, 9, 19, 12, 20, 0, 0, loc), time.Date(2000, 10, 17, 15, 50, 0, 0, loc), "throughout"}}},
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. (Subgroup). Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "Subgroup", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"SubgroupBeforeType",
+			args{&RawEvent{data: "Физика 1 п/г. лабораторные занятия. Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Физика", Type: "lab", Subgroup: "1 п/г", Location: "Location", SubgroupNumber: 1, Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"SubgroupBeforeTeacher",
+			args{&RawEvent{data: "Физика. 2 подгруппа. Teacher T.T. лабораторные занятия. Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Физика", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "2 подгруппа", Location: "Location", SubgroupNumber: 2, Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"ParenthesizedSubgroup",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. (1 подгруппа). Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "1 подгруппа", SubgroupNumber: 1, Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"AbbreviatedSubgroup",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. подгр.2. Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "подгр.2", SubgroupNumber: 2, Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"SlashSubgroup",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. 3 п/г. Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "3 п/г", SubgroupNumber: 3, Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"SubgroupAfterLocation",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. Location. (подгр. 1). [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "подгр. 1", SubgroupNumber: 1, Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"NumericSubgroup",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. (2). Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "2", SubgroupNumber: 2, Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"LeadingTime",
+			args{&RawEvent{data: "10:15-11:45 Title. Teacher T.T. лабораторные занятия. Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 10, 15, 0, 0, loc), End: time.Date(2000, 10, 17, 11, 45, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"Cancelled",
+			args{&RawEvent{data: "Занятий нет. [19.09]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Cancelled: true, Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 9, 19, 14, 0, 0, 0, loc), Frequency: "once"}}},
+			false,
+		},
+		{
+			"CancelledWithTitle",
+			args{&RawEvent{data: "Title. нет занятий [19.09]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Cancelled: true, Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 9, 19, 14, 0, 0, 0, loc), Frequency: "once"}}},
+			false,
+		},
+		{
+			"CancelledInNote",
+			args{&RawEvent{data: "Физика. Иванов И.И. лекции. 101. [19.09-17.10 к.н.] (12.10 занятий нет)", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Физика", Teacher: "Иванов И.И.", Type: "lecture", Location: "101", Note: "12.10 занятий нет", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 14, 0, 0, 0, loc), Frequency: "every"}}},
+			false,
+		},
+		{
+			"Practice",
+			args{&RawEvent{data: "Учебная практика. Teacher T.T. Location. [05.09-18.09]", position: pdf.Point{X: 46, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Учебная практика", Teacher: "Teacher T.T.", Type: "practice", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 5, 0, 0, 0, 0, loc), End: time.Date(2000, 9, 18, 23, 59, 0, 0, loc), Frequency: "continuous"}}},
+			false,
+		},
+		{
+			"PracticeInTitle",
+			args{&RawEvent{data: "Производственная практика: организация. Иванов И.И. лекции. 101. [19.09]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Производственная практика: организация", Teacher: "Иванов И.И.", Type: "lecture", Location: "101", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 9, 19, 14, 0, 0, 0, loc), Frequency: "once"}}},
+			false,
+		},
+		{
+			"PracticeIncorrectRange",
+			args{&RawEvent{data: "Учебная практика. [05.09-18.29]", position: pdf.Point{X: 46, Y: 513}, initialDate: initialDate}},
+			nil,
+			true,
+		},
+		{
+			"LeadingDates",
+			args{&RawEvent{data: "[19.09-17.10 ч.н.] Title. Teacher T.T. лабораторные занятия. Location", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"ThreeSegmentTail",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. (Subgroup). Location. Extra. [19.09-17.10 ч.н.] (Note)", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "Subgroup", Location: "Location", Note: "Extra; Note", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"CapitalizedType",
+			args{&RawEvent{data: "Title. Teacher T.T. Лекции. Location. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lecture", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: "once"}}},
+			false,
+		},
+		{
+			"UppercaseType",
+			args{&RawEvent{data: "Title. Teacher T.T. СЕМИНАР. Location. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "seminar", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: "once"}}},
+			false,
+		},
+		{
+			"CommonForAllSubgroups",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. (для всех подгрупп). Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Location: "Location", Common: true, Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"LabSubgroupTwoSlots",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. подгр.2. Location. [05.09]", position: pdf.Point{X: 46, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "подгр.2", SubgroupNumber: 2, Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 12, 0, 0, 0, loc), Frequency: "once"}}},
+			false,
+		},
+		{
+			"LocationBeforeSubgroup",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. Location. (Subgroup). [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "Subgroup", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"LocationBeforeSubgroupPattern",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. Location. (2 подгруппа). [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "2 подгруппа", SubgroupNumber: 2, Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"WhitespaceSubgroup",
+			args{&RawEvent{data: "Title. Teacher T.T. лабораторные занятия. ( ). Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"DoubleSpaceSeparators",
+			args{&RawEvent{data: "Title.  Teacher T.T. лабораторные занятия.  (Subgroup).  Location. [19.09-17.10 ч.н.]", position: pdf.Point{X: 233, Y: 513}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "Subgroup", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 19, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 17, 15, 50, 0, 0, loc), Frequency: "throughout"}}},
+			false,
+		},
+		{
+			"WhitespaceTeacher",
+			args{&RawEvent{data: "Title.   лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}},
+			&Event{Title: "Title", Type: "lecture", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every"}}},
+			false,
+		},
+		{
+			"WithNote",
+			args{&RawEvent{data: "Title. Teacher T.T. лекции. Location. [05.09-05.12 к.н.] (перенос на 20.10)", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}},
+			&Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lecture", Location: "Location", Dates: []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: "every"}}, Note: "перенос на 20.10"},
 			false,
 		},
 		{
 			"TypeNotFoundError",
-			args{&RawEvent{"Title. Teacher T.T. Unknown. Location. [05.09-05.12 к.н.]", pdf.Point{X: 0, Y: 0}, initialDate}},
+			args{&RawEvent{data: "Title. Teacher T.T. Unknown. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 0, Y: 0}, initialDate: initialDate}},
 			nil,
 			true,
 		},
@@ -56,3 +234,508 @@ func Test_parseEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestEvent_Clone(t *testing.T) {
+	event := Event{
+		Title:     "Title",
+		Dates:     []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce}},
+		RawDates:  []string{"05.09"},
+		Subgroups: []string{"1", "2"},
+	}
+	want := Event{
+		Title:     "Title",
+		Dates:     []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce}},
+		RawDates:  []string{"05.09"},
+		Subgroups: []string{"1", "2"},
+	}
+
+	clone := event.Clone()
+	if !reflect.DeepEqual(clone, want) {
+		t.Fatalf("Event.Clone() = %v, want %v", clone, want)
+	}
+	clone.Title = "Clone"
+	clone.Dates[0].Start = clone.Dates[0].Start.AddDate(0, 0, 7)
+	clone.Dates = append(clone.Dates, EventDate{})
+	clone.RawDates[0] = "12.09"
+	clone.Subgroups[1] = "3"
+	if !reflect.DeepEqual(event, want) {
+		t.Errorf("event = %v after mutating clone, want %v", event, want)
+	}
+}
+
+func Test_parseEvent_errors(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+	tests := []struct {
+		name string
+		raw  *RawEvent
+		want error
+	}{
+		{"TypeNotFound", &RawEvent{data: "Title. Teacher T.T. Unknown. Location. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}, ErrTypeNotFound},
+		{"DateParse", &RawEvent{data: "Title. Teacher T.T. лекции. Location. [05.09-05.12]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}, ErrDateParse},
+		{"OpenEndedTimeParse", &RawEvent{data: "Title. Teacher T.T. лекции. Location. [05.09 с 25:00]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}, ErrDateParse},
+		{"DatesNotFound", &RawEvent{data: "Title. Teacher T.T. лекции. Location.", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}, ErrMalformedCell},
+		{"TypeFirst", &RawEvent{data: "лекции. Location. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}, ErrMalformedCell},
+		{"SubgroupFirst", &RawEvent{data: "1 п/г. лекции. Location. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}, ErrMalformedCell},
+		{"SpaceBeforeType", &RawEvent{data: " лекции. Location. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}, ErrMalformedCell},
+		{"ShiftOutOfRange", &RawEvent{data: "Title. Teacher T.T. лабораторные занятия. Location. [05.09]", position: pdf.Point{X: 700, Y: 0}, initialDate: initialDate}, ErrMalformedCell},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseEvent(tt.raw); !errors.Is(err, tt.want) {
+				t.Errorf("parseEvent() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newTypeRegexp(t *testing.T) {
+	types := map[string]string{
+		"лаб":          "short",
+		"лаб. занятия": "lab",
+		"семинар":      "seminar",
+	}
+	typeRegexp := newTypeRegexp(types)
+
+	if want := `(?i)(лаб\. занятия|семинар|лаб)\.`; typeRegexp.String() != want {
+		t.Errorf("newTypeRegexp() = %s, want %s", typeRegexp, want)
+	}
+	if got, want := typeRegexp.FindString("Title. Teacher T.T. лаб. занятия. Location."), "лаб. занятия."; got != want {
+		t.Errorf("FindString() = %q, want %q", got, want)
+	}
+	if got, want := typeRegexp.FindString("Title. Teacher T.T. СЕМИНАР. Location."), "СЕМИНАР."; got != want {
+		t.Errorf("FindString() = %q, want %q", got, want)
+	}
+}
+
+func Test_typeRegexp(t *testing.T) {
+	types := map[string]string{"лекции": "lecture", "семинар": "seminar"}
+	same := map[string]string{"семинар": "seminar", "лекции": "lecture"}
+
+	var wg sync.WaitGroup
+	regexps := make([]*regexp.Regexp, 8)
+	for i := range regexps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			regexps[i] = typeRegexp(same)
+		}(i)
+	}
+	wg.Wait()
+
+	want := typeRegexp(types)
+	for i, got := range regexps {
+		if got != want {
+			t.Errorf("typeRegexp() [%d] = %p, want cached %p", i, got, want)
+		}
+	}
+	if other := typeRegexp(map[string]string{"лекции": "lecture"}); other == want {
+		t.Errorf("typeRegexp() returns the same regexp for different keywords")
+	}
+}
+
+func BenchmarkTypeRegexp(b *testing.B) {
+	b.Run("Uncached", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				newTypeRegexp(eventTypes)
+			}
+		})
+	})
+	b.Run("Cached", func(b *testing.B) {
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				typeRegexp(eventTypes)
+			}
+		})
+	})
+}
+
+func Test_joinLines(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		text string
+		want string
+	}{
+		{"Space", "Title.", "T", "Title. T"},
+		{"WrappedWord", "Математи-", "к", "Математик"},
+		{"CompoundWord", "Северо-", "З", "Северо-З"},
+		{"Dash", "05.09-", "1", "05.09- 1"},
+		{"SeparateHyphen", "Title -", "к", "Title - к"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := joinLines(tt.data, tt.text); got != tt.want {
+				t.Errorf("joinLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	var texts []pdf.Text
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 500, S: "Математи-"},
+		{X: 46, Y: 490, S: "ка. лекции. Location. [05.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+	rawEvents, err := NewParser().getRawEvents(texts, nil, time.Time{})
+	if err != nil {
+		t.Fatalf("Parser.getRawEvents() error = %v", err)
+	}
+	if want := "Математика. лекции. Location. [05.09]"; len(rawEvents) != 1 || rawEvents[0].data != want {
+		t.Errorf("Parser.getRawEvents() = %v, want single raw event with data %q", rawEvents, want)
+	}
+}
+
+func Test_getRawEvents_negativeY(t *testing.T) {
+	var texts []pdf.Text
+	for _, r := range "Title. лекции. Location. [05.09]" {
+		texts = append(texts, pdf.Text{X: 46, Y: -20, S: string(r)})
+	}
+	rawEvents, err := NewParser().getRawEvents(texts, nil, time.Time{})
+	if err != nil {
+		t.Fatalf("Parser.getRawEvents() error = %v", err)
+	}
+	if want := "Title. лекции. Location. [05.09]"; len(rawEvents) != 1 || rawEvents[0].data != want {
+		t.Errorf("Parser.getRawEvents() = %v, want single raw event with data %q", rawEvents, want)
+	}
+}
+
+func Test_getRawEvents(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 500, S: "Title. лекции. Location. [05.09]"},
+		{X: 46, Y: 490, S: " (перенос"},
+		{X: 46, Y: 480, S: "на 20.10)"},
+		{X: 139, Y: 500, S: "Next. лекции. Location. [06.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+
+	want := []RawEvent{
+		{data: "Title. лекции. Location. [05.09] (перенос на 20.10)", position: pdf.Point{X: 46, Y: 500}, initialDate: initialDate, config: &rawEventConfig{}},
+		{data: "Next. лекции. Location. [06.09]", position: pdf.Point{X: 139, Y: 500}, initialDate: initialDate, textIndex: 50, config: &rawEventConfig{}},
+	}
+	got, err := NewParser().getRawEvents(texts, nil, initialDate)
+	if err != nil {
+		t.Fatalf("Parser.getRawEvents() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parser.getRawEvents() = %v, want %v", got, want)
+	}
+
+	limits := []struct {
+		name string
+		opts []Option
+		err  error
+	}{
+		{"MaxEvents", []Option{WithMaxEvents(1)}, ErrTooManyEvents},
+		{"MaxCellLength", []Option{WithMaxCellLength(40)}, ErrCellTooLong},
+		{"MaxNoteLength", []Option{WithMaxCellLength(len(want[0].data) - 1)}, ErrCellTooLong},
+	}
+	for _, tt := range limits {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewParser(tt.opts...).getRawEvents(texts, nil, initialDate); !errors.Is(err, tt.err) {
+				t.Errorf("Parser.getRawEvents() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+	if _, err := NewParser(WithMaxEvents(2), WithMaxCellLength(len(want[0].data))).getRawEvents(texts, nil, initialDate); err != nil {
+		t.Errorf("Parser.getRawEvents() error = %v at limits", err)
+	}
+
+	t.Run("Segments", func(t *testing.T) {
+		got, err := NewParser(WithSegments()).getRawEvents(texts, nil, initialDate)
+		if err != nil {
+			t.Fatalf("Parser.getRawEvents() error = %v", err)
+		}
+		want := [][]string{
+			{"Title. лекции. Location. [05.09]", "(перенос", "на 20.10)"},
+			{"Next. лекции. Location. [06.09]"},
+		}
+		for i, raw := range got {
+			if !reflect.DeepEqual(raw.Segments(), want[i]) {
+				t.Errorf("rawEvents[%d].Segments() = %q, want %q", i, raw.Segments(), want[i])
+			}
+		}
+	})
+}
+
+func Test_getRawEvents_url(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 500, S: "Title. лекции. https://example.com/j?room[id]=5 [05.09]"},
+		{X: 139, Y: 500, S: "Next. лекции. (https://example.com/j?room[id]=6) [06.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+
+	want := []RawEvent{
+		{data: "Title. лекции. https://example.com/j?room[id]=5 [05.09]", position: pdf.Point{X: 46, Y: 500}, initialDate: initialDate, config: &rawEventConfig{}},
+		{data: "Next. лекции. (https://example.com/j?room[id]=6) [06.09]", position: pdf.Point{X: 139, Y: 500}, initialDate: initialDate, textIndex: 55, config: &rawEventConfig{}},
+	}
+	got, err := NewParser().getRawEvents(texts, nil, initialDate)
+	if err != nil {
+		t.Fatalf("Parser.getRawEvents() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parser.getRawEvents() = %v, want %v", got, want)
+	}
+}
+
+func Test_getRawEvents_bareNote(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 500, S: "Title. лекции. Location. [05.09] перенос на 20.10"},
+		{X: 139, Y: 500, S: "Next. лекции. Location. [06.09]"},
+		{X: 46, Y: 480, S: "Below. лекции. Location. [12.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+	pageStarts := []reader.PageStart{{Number: 1, Index: 0}, {Number: 2, Index: len(texts)}}
+	for _, r := range "Second. лекции. Location. [19.09]" {
+		texts = append(texts, pdf.Text{X: 46, Y: 480, S: string(r)})
+	}
+
+	got, err := NewParser().getRawEvents(texts, pageStarts, initialDate)
+	if err != nil {
+		t.Fatalf("Parser.getRawEvents() error = %v", err)
+	}
+	data := make([]string, len(got))
+	for i := range got {
+		data[i] = got[i].data
+	}
+	want := []string{
+		"Title. лекции. Location. [05.09] перенос на 20.10",
+		"Next. лекции. Location. [06.09]",
+		"Below. лекции. Location. [12.09]",
+		"Second. лекции. Location. [19.09]",
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Parser.getRawEvents() data = %q, want %q", data, want)
+	}
+
+	event, err := parseEvent(&got[0])
+	if err != nil {
+		t.Fatalf("parseEvent() error = %v", err)
+	}
+	if event.Title != "Title" || event.Note != "перенос на 20.10" {
+		t.Errorf("parseEvent() title, note = %q, %q, want %q, %q", event.Title, event.Note, "Title", "перенос на 20.10")
+	}
+}
+
+func TestParser_parseEvents(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	rawEvents := []RawEvent{
+		{data: "Title. Teacher T.T. лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+		{data: "Title. Teacher T.T. Unknown. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+	}
+
+	t.Run("WithoutErrorHandler", func(t *testing.T) {
+		if _, err := NewParser().parseEvents(rawEvents); err == nil {
+			t.Errorf("Parser.parseEvents() error = %v, wantErr %v", err, true)
+		}
+	})
+
+	t.Run("WithErrorHandler", func(t *testing.T) {
+		var indexes []int
+		handler := func(index int, raw RawEvent, err error) {
+			indexes = append(indexes, index)
+			if raw.Data() != rawEvents[1].data {
+				t.Errorf("raw.Data() = %q, want %q", raw.Data(), rawEvents[1].data)
+			}
+		}
+		events, err := NewParser(WithErrorHandler(handler)).parseEvents(rawEvents)
+		if err != nil {
+			t.Fatalf("Parser.parseEvents() error = %v", err)
+		}
+		if !reflect.DeepEqual(indexes, []int{1}) {
+			t.Errorf("handler indexes = %v, want %v", indexes, []int{1})
+		}
+		if len(events) != 1 || events[0].Type != "lecture" {
+			t.Errorf("Parser.parseEvents() = %v, want single lecture", events)
+		}
+	})
+}
+
+func BenchmarkParser_parseEvents(b *testing.B) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	rawEvents := make([]RawEvent, 10000)
+	for i := range rawEvents {
+		rawEvents[i] = RawEvent{data: "Title. Teacher T.T. лекции. Location. [05.09-05.12 к.н., 12.12, 19.12]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate}
+	}
+	parser := NewParser()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parser.parseEvents(rawEvents); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestWithMaxDateSpan(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	rawEvents := []RawEvent{
+		{data: "Title. Teacher T.T. лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+	}
+
+	tests := []struct {
+		name    string
+		n       int
+		wantErr bool
+	}{
+		{"WithoutLimit", 0, false},
+		{"AtLimit", 91, false},
+		{"BeyondLimit", 90, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser(WithMaxDateSpan(tt.n)).parseEvents(rawEvents)
+			var spanErr *DateSpanError
+			if errors.As(err, &spanErr) != tt.wantErr {
+				t.Fatalf("Parser.parseEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && (spanErr.Event.Title != "Title" || spanErr.Days != 91) {
+				t.Errorf("DateSpanError = %+v, want event %q spanning %d days", spanErr, "Title", 91)
+			}
+		})
+	}
+}
+
+func TestWithMaxOccurrences(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	rawEvents := []RawEvent{
+		{data: "Title. Teacher T.T. лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+	}
+
+	tests := []struct {
+		name    string
+		n       int
+		wantErr bool
+	}{
+		{"WithoutLimit", 0, false},
+		{"AtLimit", 14, false},
+		{"BeyondLimit", 13, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser(WithMaxOccurrences(tt.n)).parseEvents(rawEvents)
+			if errors.Is(err, ErrTooManyOccurrences) != tt.wantErr {
+				t.Errorf("Parser.parseEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithRawDates(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	rawEvents := []RawEvent{
+		{data: "Title. Teacher T.T. лекции. Location. [05.09–05.12 к.н., 19.12 с 14:00]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+	}
+
+	events, err := NewParser(WithRawDates()).parseEvents(rawEvents)
+	if err != nil {
+		t.Fatalf("Parser.parseEvents() error = %v", err)
+	}
+	want := []string{"05.09–05.12 к.н.", "19.12 с 14:00"}
+	if !reflect.DeepEqual(events[0].RawDates, want) {
+		t.Errorf("Event.RawDates = %q, want %q", events[0].RawDates, want)
+	}
+	if len(events[0].Dates) != 2 {
+		t.Errorf("len(Event.Dates) = %d, want %d", len(events[0].Dates), 2)
+	}
+
+	events, err = NewParser().parseEvents(rawEvents)
+	if err != nil {
+		t.Fatalf("Parser.parseEvents() error = %v", err)
+	}
+	if events[0].RawDates != nil {
+		t.Errorf("Event.RawDates = %q, want nil", events[0].RawDates)
+	}
+}
+
+func TestWithNormalizeTeacher(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	rawEvents := []RawEvent{
+		{data: "Title. Иванов И. И. лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+	}
+
+	events, err := NewParser(WithNormalizeTeacher()).parseEvents(rawEvents)
+	if err != nil {
+		t.Fatalf("Parser.parseEvents() error = %v", err)
+	}
+	if events[0].Teacher != "Иванов И.И." || events[0].RawTeacher != "Иванов И. И." {
+		t.Errorf("Event.Teacher, Event.RawTeacher = %q, %q, want %q, %q", events[0].Teacher, events[0].RawTeacher, "Иванов И.И.", "Иванов И. И.")
+	}
+}
+
+func TestWithStrictSplit(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	rawEvents := []RawEvent{
+		{data: "Title. Иванов И. И. лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+		{data: "Title. Subtitle. Teacher T.T. лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+	}
+
+	t.Run("Heuristic", func(t *testing.T) {
+		events, err := NewParser().parseEvents(rawEvents)
+		if err != nil {
+			t.Fatalf("Parser.parseEvents() error = %v", err)
+		}
+		if events[1].Teacher != "Subtitle. Teacher T.T." {
+			t.Errorf("Event.Teacher = %q, want %q", events[1].Teacher, "Subtitle. Teacher T.T.")
+		}
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		var errs []error
+		handler := func(index int, raw RawEvent, err error) {
+			errs = append(errs, err)
+		}
+		events, err := NewParser(WithStrictSplit(), WithErrorHandler(handler)).parseEvents(rawEvents)
+		if err != nil {
+			t.Fatalf("Parser.parseEvents() error = %v", err)
+		}
+		if len(events) != 1 || events[0].Teacher != "Иванов И. И." {
+			t.Errorf("Parser.parseEvents() = %v, want single event of Иванов И. И.", events)
+		}
+		var splitErr *SplitError
+		if len(errs) != 1 || !errors.Is(errs[0], ErrAmbiguousSplit) || !errors.As(errs[0], &splitErr) {
+			t.Fatalf("handler errors = %v, want single SplitError", errs)
+		}
+		want := []string{"Title", "Subtitle", "Teacher T.T."}
+		if !reflect.DeepEqual(splitErr.Segments, want) {
+			t.Errorf("SplitError.Segments = %q, want %q", splitErr.Segments, want)
+		}
+	})
+}
+
+func TestWithTrimTitleSuffixes(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+	rawEvents := []RawEvent{
+		{data: "Физика (Б1.О.12). Teacher T.T. лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+		{data: "Химия. Teacher T.T. лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+	}
+
+	events, err := NewParser(WithTrimTitleSuffixes()).parseEvents(rawEvents)
+	if err != nil {
+		t.Fatalf("Parser.parseEvents() error = %v", err)
+	}
+	if got := events[0]; got.Title != "Физика" || got.CourseCode != "Б1.О.12" || got.RawTitle != "Физика (Б1.О.12)" {
+		t.Errorf("Event.Title, Event.CourseCode, Event.RawTitle = %q, %q, %q, want %q, %q, %q", got.Title, got.CourseCode, got.RawTitle, "Физика", "Б1.О.12", "Физика (Б1.О.12)")
+	}
+	if got := events[1]; got.Title != "Химия" || got.CourseCode != "" || got.RawTitle != "" {
+		t.Errorf("Event.Title, Event.CourseCode, Event.RawTitle = %q, %q, %q, want %q, %q, %q", got.Title, got.CourseCode, got.RawTitle, "Химия", "", "")
+	}
+}
@@ -0,0 +1,98 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// expandedOccurrence is json shape of occurrence written by WriteExpandedJSON.
+type expandedOccurrence struct {
+	Date     string `json:"date"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Title    string `json:"title"`
+	Teacher  string `json:"teacher,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Subgroup string `json:"subgroup,omitempty"`
+	Location string `json:"location,omitempty"`
+	Note     string `json:"note,omitempty"`
+	Group    string `json:"group,omitempty"`
+	// ISOYear, ISOWeek and ISOWeekday are set with WithISOWeek.
+	ISOYear    int `json:"isoYear,omitempty"`
+	ISOWeek    int `json:"isoWeek,omitempty"`
+	ISOWeekday int `json:"isoWeekday,omitempty"`
+}
+
+// expandedOptions contains settings of WriteExpandedJSON.
+type expandedOptions struct {
+	isoWeek        bool
+	maxOccurrences int
+}
+
+// ExpandedOption configures WriteExpandedJSON.
+type ExpandedOption func(*expandedOptions)
+
+// WithISOWeek makes WriteExpandedJSON add ISO 8601 year and week of date of each occurrence
+// and its weekday numbered from 1 for Monday to 7 for Sunday, e.g. "isoYear": 2004, "isoWeek": 53, "isoWeekday": 6
+// for 2005-01-01.
+func WithISOWeek() ExpandedOption {
+	return func(o *expandedOptions) {
+		o.isoWeek = true
+	}
+}
+
+// WithExpandedMaxOccurrences makes WriteExpandedJSON return error wrapping ErrTooManyOccurrences
+// without writing occurrences if any event occurs more than n times. Zero n means no limit.
+func WithExpandedMaxOccurrences(n int) ExpandedOption {
+	return func(o *expandedOptions) {
+		o.maxOccurrences = n
+	}
+}
+
+// WriteExpandedJSON writes occurrences of events to w in chronological order as json objects separated by newlines,
+// one flat object per occurrence with "YYYY-MM-DD" date, "HH:MM" start and end times and details of event,
+// for clients that can't expand recurrences. Cancelled events are skipped.
+func WriteExpandedJSON(events []Event, w io.Writer, opts ...ExpandedOption) error {
+	var o expandedOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for i := range events {
+		if events[i].Cancelled {
+			continue
+		}
+		if err := checkOccurrenceCount(&events[i], o.maxOccurrences); err != nil {
+			return err
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	for _, occurrence := range eventOccurrences(events) {
+		if occurrence.event.Cancelled {
+			continue
+		}
+		expanded := expandedOccurrence{
+			Date:     occurrence.Date.Format(jsonDateLayout),
+			Start:    occurrence.Start.Format(jsonTimeLayout),
+			End:      occurrence.End.Format(jsonTimeLayout),
+			Title:    occurrence.event.Title,
+			Teacher:  occurrence.event.Teacher,
+			Type:     occurrence.event.Type,
+			Subgroup: occurrence.event.Subgroup,
+			Location: occurrence.event.Location,
+			Note:     occurrence.event.Note,
+			Group:    occurrence.event.Group,
+		}
+		if o.isoWeek {
+			expanded.ISOYear, expanded.ISOWeek = occurrence.Date.ISOWeek()
+			expanded.ISOWeekday = (int(occurrence.Date.Weekday())+6)%7 + 1
+		}
+		if err := encoder.Encode(expanded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
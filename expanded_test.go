@@ -0,0 +1,81 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWriteExpandedJSON(t *testing.T) {
+	events := []Event{
+		{Title: "Weekly", Type: "lecture", Location: "101", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 10, 20, 0, 0, loc), End: time.Date(2000, 9, 19, 12, 0, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+		{Title: "Once", Teacher: "Teacher T.T.", Type: "seminar", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 12, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "Cancelled", Cancelled: true, Dates: []EventDate{
+			{Start: time.Date(2000, 9, 6, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 6, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+	want := `{"date":"2000-09-05","start":"10:20","end":"12:00","title":"Weekly","type":"lecture","location":"101"}` + "\n" +
+		`{"date":"2000-09-12","start":"08:30","end":"10:10","title":"Once","teacher":"Teacher T.T.","type":"seminar"}` + "\n" +
+		`{"date":"2000-09-12","start":"10:20","end":"12:00","title":"Weekly","type":"lecture","location":"101"}` + "\n" +
+		`{"date":"2000-09-19","start":"10:20","end":"12:00","title":"Weekly","type":"lecture","location":"101"}` + "\n"
+
+	var buf bytes.Buffer
+	if err := WriteExpandedJSON(events, &buf); err != nil {
+		t.Fatalf("WriteExpandedJSON() error = %v", err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("WriteExpandedJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestWithExpandedMaxOccurrences(t *testing.T) {
+	events := []Event{
+		{Title: "Weekly", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 10, 20, 0, 0, loc), End: time.Date(2000, 9, 19, 12, 0, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+		{Title: "Cancelled", Cancelled: true, Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteExpandedJSON(events, &buf, WithExpandedMaxOccurrences(2)); !errors.Is(err, ErrTooManyOccurrences) {
+		t.Errorf("WriteExpandedJSON() error = %v, want %v", err, ErrTooManyOccurrences)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("WriteExpandedJSON() = %s, want empty", buf.String())
+	}
+	if err := WriteExpandedJSON(events, &buf, WithExpandedMaxOccurrences(3)); err != nil {
+		t.Errorf("WriteExpandedJSON() error = %v", err)
+	}
+}
+
+func TestWithISOWeek(t *testing.T) {
+	events := []Event{
+		{Title: "Weekly", Dates: []EventDate{
+			{Start: time.Date(2004, 12, 27, 8, 30, 0, 0, loc), End: time.Date(2005, 1, 10, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+		{Title: "Saturday", Dates: []EventDate{
+			{Start: time.Date(2005, 1, 1, 8, 30, 0, 0, loc), End: time.Date(2005, 1, 1, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+	want := `{"date":"2004-12-27","start":"08:30","end":"10:10","title":"Weekly","isoYear":2004,"isoWeek":53,"isoWeekday":1}` + "\n" +
+		`{"date":"2005-01-01","start":"08:30","end":"10:10","title":"Saturday","isoYear":2004,"isoWeek":53,"isoWeekday":6}` + "\n" +
+		`{"date":"2005-01-03","start":"08:30","end":"10:10","title":"Weekly","isoYear":2005,"isoWeek":1,"isoWeekday":1}` + "\n" +
+		`{"date":"2005-01-10","start":"08:30","end":"10:10","title":"Weekly","isoYear":2005,"isoWeek":2,"isoWeekday":1}` + "\n"
+
+	var buf bytes.Buffer
+	if err := WriteExpandedJSON(events, &buf, WithISOWeek()); err != nil {
+		t.Fatalf("WriteExpandedJSON() error = %v", err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("WriteExpandedJSON() = %s, want %s", got, want)
+	}
+}
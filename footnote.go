@@ -0,0 +1,61 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+var (
+	// footnoteRegexp matches footnote line starting with marker.
+	footnoteRegexp = regexp.MustCompile(`^\s*(\*+|[¹²³⁴⁵⁶⁷⁸⁹⁰]+)\s*(.*)$`)
+	// markerRegexp matches footnote markers in event cell.
+	markerRegexp = regexp.MustCompile(`\*+|[¹²³⁴⁵⁶⁷⁸⁹⁰]+`)
+)
+
+// getFootnotes takes slice of pdf.Text and returns texts of footnotes below top Y coordinate by their markers.
+// Consecutive texts with the same Y coordinate form a line, and line without marker continues previous footnote.
+func getFootnotes(texts []pdf.Text, top float64) map[string]string {
+	footnotes := make(map[string]string)
+	var (
+		line, marker string
+		y            float64
+	)
+	flush := func() {
+		if submatches := footnoteRegexp.FindStringSubmatch(line); submatches != nil {
+			marker = submatches[1]
+			footnotes[marker] = strings.TrimSpace(submatches[2])
+		} else if marker != "" && strings.TrimSpace(line) != "" {
+			footnotes[marker] = strings.TrimSpace(footnotes[marker] + " " + strings.TrimSpace(line))
+		}
+		line = ""
+	}
+	for _, text := range texts {
+		if text.Y >= top {
+			continue
+		}
+		if line != "" && text.Y != y {
+			flush()
+		}
+		line += text.S
+		y = text.Y
+	}
+	flush()
+	return footnotes
+}
+
+// resolveFootnotes removes footnote markers from data of raw event
+// and keeps texts of corresponding footnotes. Markers without footnote are left in data.
+func (raw *RawEvent) resolveFootnotes(footnotes map[string]string) {
+	raw.data = markerRegexp.ReplaceAllStringFunc(raw.data, func(marker string) string {
+		footnote, ok := footnotes[marker]
+		if !ok {
+			return marker
+		}
+		raw.footnotes = append(raw.footnotes, footnote)
+		return ""
+	})
+}
@@ -0,0 +1,145 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// typeKeyword returns keyword of event type in pdf content.
+// If several keywords have the same type, the first one in sorted order is returned.
+func typeKeyword(eventType string) string {
+	keywords := make([]string, 0, 1)
+	for keyword, t := range eventTypes {
+		if t == eventType {
+			keywords = append(keywords, keyword)
+		}
+	}
+	if len(keywords) == 0 {
+		return eventType
+	}
+	sort.Strings(keywords)
+	return keywords[0]
+}
+
+// parityNames maps parity of weeks to its name written for group of dates in pdf content.
+var parityNames = map[Parity]string{
+	ParityEven: "чётная",
+	ParityOdd:  "нечётная",
+}
+
+// formatDate returns event date as it is written in brackets of pdf content.
+// Parity of date is written for its group by FormatEvent.
+func formatDate(date *EventDate) string {
+	var s string
+	switch {
+	case date.OpenEndedRange && date.Frequency == FrequencyThroughout:
+		s = "с " + date.Start.Format(dateFormat) + " ч.н."
+	case date.OpenEndedRange:
+		s = "с " + date.Start.Format(dateFormat)
+	case date.Frequency == FrequencyEvery:
+		s = fmt.Sprintf("%s-%s к.н.", date.Start.Format(dateFormat), date.End.Format(dateFormat))
+	case date.Frequency == FrequencyThroughout:
+		s = fmt.Sprintf("%s-%s ч.н.", date.Start.Format(dateFormat), date.End.Format(dateFormat))
+	case date.Frequency == FrequencyContinuous:
+		s = fmt.Sprintf("%s-%s", date.Start.Format(dateFormat), date.End.Format(dateFormat))
+	default:
+		s = date.Start.Format(dateFormat)
+	}
+	if len(date.Exceptions) > 0 {
+		exceptions := make([]string, len(date.Exceptions))
+		for i, exception := range date.Exceptions {
+			exceptions[i] = exception.Format(dateFormat)
+		}
+		s += " кроме " + strings.Join(exceptions, " и ")
+	}
+	if date.OpenEnded {
+		s += " с " + date.Start.Format("15:04")
+	}
+	return s
+}
+
+// FormatEvent reconstructs text of pdf cell that event is parsed from:
+// "Title. Teacher. type. (Subgroup). Location. [dates] (note)".
+// Result isn't byte-identical to source text, but it is parsed to equal event.
+// Consecutive dates of the same parity form group of dates ending with parity, e.g. "[14.09, 28.09 (чётная); 21.09 (нечётная)]".
+// Event times aren't included, since they are determined by cell position, and end of open-ended date range
+// is determined by semester range of header.
+func FormatEvent(event Event) string {
+	segments := make([]string, 0, 5)
+	segments = append(segments, event.Title)
+	if event.Teacher != "" {
+		segments = append(segments, event.Teacher)
+	}
+	// Type of practice block is determined by its title.
+	if event.Type != practiceType {
+		segments = append(segments, typeKeyword(event.Type))
+	}
+	if event.Subgroup != "" {
+		segments = append(segments, "("+event.Subgroup+")")
+	}
+	segments = append(segments, event.Location)
+
+	groups := make([]string, 0, 1)
+	for i := 0; i < len(event.Dates); {
+		parity := event.Dates[i].Parity
+		dates := make([]string, 0, 1)
+		for ; i < len(event.Dates) && event.Dates[i].Parity == parity; i++ {
+			dates = append(dates, formatDate(&event.Dates[i]))
+		}
+		group := strings.Join(dates, ", ")
+		if parity != "" {
+			group += " (" + parityNames[parity] + ")"
+		}
+		groups = append(groups, group)
+	}
+
+	var builder strings.Builder
+	for _, segment := range segments {
+		builder.WriteString(segment)
+		// Period of initials is also separator of segments.
+		if !strings.HasSuffix(segment, ".") {
+			builder.WriteByte('.')
+		}
+		builder.WriteByte(' ')
+	}
+
+	s := fmt.Sprintf("%s[%s]", builder.String(), strings.Join(groups, "; "))
+	if event.Note != "" {
+		s += " (" + event.Note + ")"
+	}
+	return s
+}
+
+// String returns Summary of event at current time.
+func (event Event) String() string {
+	return event.Summary(time.Now())
+}
+
+// Summary returns one-line summary of event at time now:
+// "Title (type) — Location, Teacher, next: 2006-01-02 15:04".
+// Empty location and teacher are omitted, as is next occurrence if event has already ended by now.
+func (event Event) Summary(now time.Time) string {
+	s := fmt.Sprintf("%s (%s)", event.Title, event.Type)
+
+	details := make([]string, 0, 3)
+	for _, detail := range []string{event.Location, event.Teacher} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	for _, occurrence := range Occurrences(event) {
+		if occurrence.End.After(now) {
+			details = append(details, "next: "+occurrence.Start.Format("2006-01-02 15:04"))
+			break
+		}
+	}
+
+	if len(details) > 0 {
+		s += " — " + strings.Join(details, ", ")
+	}
+	return s
+}
@@ -0,0 +1,110 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestFormatEvent(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		raw  RawEvent
+		want string
+	}{
+		{
+			"Lecture",
+			RawEvent{data: "Title. Teacher T.T. лекции. Location. [05.09-05.12 к.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+			"Title. Teacher T.T. лекции. Location. [05.09-05.12 к.н.]",
+		},
+		{
+			"LabWithSubgroup",
+			RawEvent{data: "Title. Teacher T.T. лабораторные занятия. (Subgroup). Location. [19.09-17.10 ч.н., 24.10]", position: pdf.Point{X: 233, Y: 0}, initialDate: initialDate},
+			"Title. Teacher T.T. лабораторные занятия. (Subgroup). Location. [19.09-17.10 ч.н., 24.10]",
+		},
+		{
+			"WithoutTeacher",
+			RawEvent{data: "Title. семинар.  Location. [12.12 с 14:00] (перенос на 20.10)", position: pdf.Point{X: 420, Y: 0}, initialDate: initialDate},
+			"Title. семинар. Location. [12.12 с 14:00] (перенос на 20.10)",
+		},
+		{
+			"Exceptions",
+			RawEvent{data: "Title. лекции. Location. [05.09-05.12 к.н. кроме 10.10 и 17.10, 12.12 с 14:00]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+			"Title. лекции. Location. [05.09-05.12 к.н. кроме 10.10 и 17.10, 12.12 с 14:00]",
+		},
+		{
+			"Parity",
+			RawEvent{data: "Title. лекции. Location. [05.09, 19.09 (чётная); 12.09 (нечётная); 26.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+			"Title. лекции. Location. [05.09, 19.09 (чётная); 12.09 (нечётная); 26.09]",
+		},
+		{
+			"ParityRange",
+			RawEvent{data: "Title. лекции. Location. [05.09-26.12 (нечётная)]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+			"Title. лекции. Location. [12.09-19.12 ч.н. (нечётная)]",
+		},
+		{
+			"OpenEndedRange",
+			RawEvent{data: "Title. лекции. Location. [с 14.09 ч.н.]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate, semester: &semester{"01.09", "28.12"}},
+			"Title. лекции. Location. [с 14.09 ч.н.]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := parseEvent(&tt.raw)
+			if err != nil {
+				t.Fatalf("parseEvent() error = %v", err)
+			}
+			got := FormatEvent(*event)
+			if got != tt.want {
+				t.Errorf("FormatEvent() = %q, want %q", got, tt.want)
+			}
+
+			raw := tt.raw
+			raw.data = got
+			reparsed, err := parseEvent(&raw)
+			if err != nil {
+				t.Fatalf("parseEvent() error = %v", err)
+			}
+			if !reflect.DeepEqual(reparsed, event) {
+				t.Errorf("parseEvent(FormatEvent()) = %v, want %v", reparsed, event)
+			}
+		})
+	}
+}
+
+func TestEvent_Summary(t *testing.T) {
+	now := time.Date(2000, 9, 6, 0, 0, 0, 0, loc)
+
+	event := Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lecture", Location: "101", Dates: []EventDate{
+		{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+	}}
+	ended := Event{Title: "Title", Type: "seminar", Dates: []EventDate{
+		{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+	}}
+
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{"Upcoming", event, "Title (lecture) — 101, Teacher T.T., next: 2000-09-12 08:30"},
+		{"Ended", ended, "Title (seminar)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.Summary(now); got != tt.want {
+				t.Errorf("Event.Summary() = %q, want %q", got, tt.want)
+			}
+			if got, want := fmt.Sprintf("%v", tt.event), tt.event.Summary(time.Now()); got != want {
+				t.Errorf("fmt.Sprintf(%%v) = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Package gcal converts schedule events to Google Calendar API event resources.
+
+package gcal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/qsoulior/scheduleparser"
+)
+
+// EventDateTime is start or end time of Google Calendar event.
+type EventDateTime struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+// Event is body of Google Calendar Events.insert request.
+type Event struct {
+	Summary     string        `json:"summary"`
+	Location    string        `json:"location,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Start       EventDateTime `json:"start"`
+	End         EventDateTime `json:"end"`
+	Recurrence  []string      `json:"recurrence,omitempty"`
+}
+
+const (
+	dateTimeLayout = "2006-01-02T15:04:05"
+	untilLayout    = "20060102T150405Z"
+)
+
+// description joins non-empty details of event into multiline string.
+func description(event *scheduleparser.Event) string {
+	lines := make([]string, 0, 4)
+	for _, line := range []string{event.Type, event.Teacher, event.Subgroup, event.Note} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recurrence returns RRULE and EXDATE of event date or nil if it occurs once.
+func recurrence(date *scheduleparser.EventDate) []string {
+	rule := rrule(date)
+	if rule == "" {
+		return nil
+	}
+	lines := []string{rule}
+	if len(date.Exceptions) > 0 {
+		exceptions := make([]string, len(date.Exceptions))
+		for i, exception := range date.Exceptions {
+			exceptions[i] = exception.UTC().Format(untilLayout)
+		}
+		lines = append(lines, "EXDATE:"+strings.Join(exceptions, ","))
+	}
+	return lines
+}
+
+// rrule returns RRULE of event date or empty string if it occurs once.
+func rrule(date *scheduleparser.EventDate) string {
+	var interval int
+	switch date.Frequency {
+	case scheduleparser.FrequencyContinuous:
+		return fmt.Sprintf("RRULE:FREQ=DAILY;UNTIL=%s", date.End.UTC().Format(untilLayout))
+	case scheduleparser.FrequencyEvery:
+		interval = 1
+	case scheduleparser.FrequencyThroughout:
+		interval = 2
+	default:
+		return ""
+	}
+	until := date.End.UTC().Format(untilLayout)
+	if interval == 1 {
+		return fmt.Sprintf("RRULE:FREQ=WEEKLY;UNTIL=%s", until)
+	}
+	return fmt.Sprintf("RRULE:FREQ=WEEKLY;INTERVAL=%d;UNTIL=%s", interval, until)
+}
+
+// ToGoogleCalendarEvents converts events to Google Calendar events in time zone tz.
+// Every event date becomes separate event starting at first occurrence
+// and recurring according to frequency of date. Cancelled events are skipped.
+func ToGoogleCalendarEvents(events []scheduleparser.Event, tz string) ([]Event, error) {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("incorrect time zone: %w", err)
+	}
+
+	calendarEvents := make([]Event, 0, len(events))
+	for i := range events {
+		event := &events[i]
+		if event.Cancelled {
+			continue
+		}
+		for j := range event.Dates {
+			date := &event.Dates[j]
+			start := date.Start.In(loc)
+			end := date.End.In(loc)
+			end = time.Date(start.Year(), start.Month(), start.Day(), end.Hour(), end.Minute(), end.Second(), 0, loc)
+
+			calendarEvents = append(calendarEvents, Event{
+				Summary:     event.Title,
+				Location:    event.Location,
+				Description: description(event),
+				Start:       EventDateTime{start.Format(dateTimeLayout), tz},
+				End:         EventDateTime{end.Format(dateTimeLayout), tz},
+				Recurrence:  recurrence(date),
+			})
+		}
+	}
+	return calendarEvents, nil
+}
@@ -0,0 +1,65 @@
+// Package gcal converts schedule events to Google Calendar API event resources.
+
+package gcal
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qsoulior/scheduleparser"
+)
+
+func TestToGoogleCalendarEvents(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	events := []scheduleparser.Event{{
+		Title:    "Title",
+		Teacher:  "Teacher T.T.",
+		Type:     "lecture",
+		Location: "Location",
+		Dates: []scheduleparser.EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: scheduleparser.FrequencyEvery,
+				Exceptions: []time.Time{time.Date(2000, 10, 10, 8, 30, 0, 0, loc), time.Date(2000, 10, 17, 8, 30, 0, 0, loc)}},
+			{Start: time.Date(2000, 9, 7, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 14, 10, 10, 0, 0, loc), Frequency: scheduleparser.FrequencyThroughout},
+			{Start: time.Date(2000, 12, 19, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 19, 10, 10, 0, 0, loc), Frequency: scheduleparser.FrequencyOnce},
+		},
+	}, {
+		Title:     "Cancelled",
+		Cancelled: true,
+		Dates: []scheduleparser.EventDate{
+			{Start: time.Date(2000, 9, 6, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 6, 10, 10, 0, 0, loc), Frequency: scheduleparser.FrequencyOnce},
+		},
+	}}
+
+	got, err := ToGoogleCalendarEvents(events, "Etc/GMT-3")
+	if err != nil {
+		t.Fatalf("ToGoogleCalendarEvents() error = %v", err)
+	}
+	want := []Event{
+		{
+			"Title", "Location", "lecture\nTeacher T.T.",
+			EventDateTime{"2000-09-05T08:30:00", "Etc/GMT-3"},
+			EventDateTime{"2000-09-05T10:10:00", "Etc/GMT-3"},
+			[]string{"RRULE:FREQ=WEEKLY;UNTIL=20001205T071000Z", "EXDATE:20001010T053000Z,20001017T053000Z"},
+		},
+		{
+			"Title", "Location", "lecture\nTeacher T.T.",
+			EventDateTime{"2000-09-07T08:30:00", "Etc/GMT-3"},
+			EventDateTime{"2000-09-07T10:10:00", "Etc/GMT-3"},
+			[]string{"RRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=20001214T071000Z"},
+		},
+		{
+			"Title", "Location", "lecture\nTeacher T.T.",
+			EventDateTime{"2000-12-19T08:30:00", "Etc/GMT-3"},
+			EventDateTime{"2000-12-19T10:10:00", "Etc/GMT-3"},
+			nil,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToGoogleCalendarEvents() = %v, want %v", got, want)
+	}
+
+	if _, err := ToGoogleCalendarEvents(events, "Unknown/Zone"); err == nil {
+		t.Errorf("ToGoogleCalendarEvents() error = %v, wantErr %v", err, true)
+	}
+}
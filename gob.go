@@ -0,0 +1,47 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"time"
+)
+
+// EncodeGob writes events to w in gob encoding, e.g. to cache parse results, which are read by DecodeGob.
+func EncodeGob(events []Event, w io.Writer) error {
+	if err := gob.NewEncoder(w).Encode(events); err != nil {
+		return fmt.Errorf("encoding error: %w", err)
+	}
+	return nil
+}
+
+// DecodeGob reads events written by EncodeGob from r.
+// Gob keeps only UTC offsets of times, so times with offset of parsed events are restored in their time zone,
+// and other times are in fixed zones with their offsets. Empty slices of events are decoded as nil.
+func DecodeGob(r io.Reader) ([]Event, error) {
+	var events []Event
+	if err := gob.NewDecoder(r).Decode(&events); err != nil {
+		return nil, fmt.Errorf("decoding error: %w", err)
+	}
+	for i := range events {
+		for j := range events[i].Dates {
+			date := &events[i].Dates[j]
+			date.Start, date.End = restoreLocation(date.Start), restoreLocation(date.End)
+			for k := range date.Exceptions {
+				date.Exceptions[k] = restoreLocation(date.Exceptions[k])
+			}
+		}
+	}
+	return events, nil
+}
+
+// restoreLocation returns t in time zone of parsed events if t is in unnamed zone with the same offset.
+func restoreLocation(t time.Time) time.Time {
+	name, offset := t.Zone()
+	if _, locOffset := t.In(loc).Zone(); name == "" && offset == locOffset {
+		return t.In(loc)
+	}
+	return t
+}
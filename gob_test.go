@@ -0,0 +1,45 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEncodeGob(t *testing.T) {
+	events := []Event{
+		{
+			Title: "Weekly", Teacher: "Teacher T.T.", Type: "lecture", Subgroup: "1 подгруппа", Location: "101", Note: "перенос",
+			Dates: []EventDate{
+				{Start: time.Date(2000, 9, 5, 10, 20, 0, 0, loc), End: time.Date(2000, 12, 26, 12, 0, 0, 0, loc), Frequency: FrequencyEvery,
+					PairNumber: 2, Exceptions: []time.Time{time.Date(2000, 10, 3, 10, 20, 0, 0, loc)}, Parity: ParityEven},
+				{Start: time.Date(2000, 9, 6, 8, 30, 0, 0, time.UTC), End: time.Date(2000, 9, 6, 8, 30, 0, 0, time.UTC), Frequency: FrequencyOnce, OpenEnded: true},
+			},
+			RawDates:       []string{"05.09-26.12 к.н."},
+			Subgroups:      []string{"1 подгруппа", "2 подгруппа"},
+			SubgroupNumber: 1,
+			Group:          "ИВТ-101",
+			Weekday:        "Tuesday",
+			MeetingURL:     "https://meet.example.com/j/1",
+			Confidence:     0.75,
+			Highlighted:    true,
+			SourceFile:     "schedule.pdf",
+		},
+		{Title: "Cancelled", Cancelled: true},
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeGob(events, &buf); err != nil {
+		t.Fatalf("EncodeGob() error = %v", err)
+	}
+	got, err := DecodeGob(&buf)
+	if err != nil {
+		t.Fatalf("DecodeGob() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, events) {
+		t.Errorf("DecodeGob() = %+v, want %+v", got, events)
+	}
+}
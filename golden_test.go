@@ -0,0 +1,100 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// loadFixture reads slice of pdf.Text from json fixture in testdata.
+// Text with several runes is split into texts with one rune and the same position,
+// as pdf reader returns them.
+func loadFixture(t *testing.T, path string) []pdf.Text {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture []pdf.Text
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+	texts := make([]pdf.Text, 0, len(fixture))
+	for _, text := range fixture {
+		for _, r := range text.S {
+			glyph := text
+			glyph.S = string(r)
+			texts = append(texts, glyph)
+		}
+	}
+	return texts
+}
+
+// goldenResult is content of golden file: parsed schedule or parsing error.
+type goldenResult struct {
+	Schedule *Schedule `json:"schedule,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// goldenOptions contains options of Parser for fixtures that require them.
+var goldenOptions = map[string][]Option{
+	"footnote": {WithFootnotes(60)},
+}
+
+// TestGolden parses every fixture in testdata and compares result with its golden file.
+// Run "go test -run TestGolden -update" to regenerate golden files.
+func TestGolden(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	for _, path := range paths {
+		if strings.HasSuffix(path, ".golden.json") {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		t.Run(name, func(t *testing.T) {
+			var result goldenResult
+			schedule, err := NewParser(goldenOptions[name]...).parseText(&reader.Content{Texts: loadFixture(t, path)}, initialDate, "")
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Schedule = schedule
+			}
+			got, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			goldenPath := filepath.Join("testdata", name+".golden.json")
+			if *update {
+				if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create golden file)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("result differs from %s:\n%s\nwant:\n%s", goldenPath, got, want)
+			}
+		})
+	}
+}
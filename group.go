@@ -0,0 +1,138 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+// groupRegexp matches student group label in header, e.g. "Группа ИВТ-101".
+var groupRegexp = regexp.MustCompile(`(?i)группа\s*:?\s*([\p{L}\d]+(?:-[\p{L}\d]+)*)`)
+
+// groupLabel is student group label found in header and X coordinate of its start.
+type groupLabel struct {
+	x    float64
+	name string
+}
+
+// getGroupLabels takes slice of pdf.Text and returns group labels of header sorted by X coordinate.
+// Consecutive texts with the same Y coordinate form a line, which may contain several labels.
+func getGroupLabels(texts []pdf.Text) []groupLabel {
+	labels := make([]groupLabel, 0)
+	const maxGap = 30
+	var (
+		line string
+		xs   []float64 // X coordinate of each byte of line
+		x, y float64
+	)
+	flush := func() {
+		for _, indexes := range groupRegexp.FindAllStringSubmatchIndex(line, -1) {
+			labels = append(labels, groupLabel{xs[indexes[0]], line[indexes[2]:indexes[3]]})
+		}
+		line, xs = "", nil
+	}
+	for _, text := range texts {
+		if text.Y < tableTop {
+			continue
+		}
+		if line != "" && text.Y != y {
+			flush()
+		}
+		// Labels on the same line are separated by gap.
+		if line != "" && (text.X < x || text.X-x > maxGap) {
+			line += " "
+			xs = append(xs, x)
+		}
+		line += text.S
+		for len(xs) < len(line) {
+			xs = append(xs, text.X)
+		}
+		x, y = text.X, text.Y
+	}
+	flush()
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i].x < labels[j].x })
+	return labels
+}
+
+// setGroups sets group of each raw event to the nearest group label at or to the left of it.
+// Tables of groups placed side by side are expected to have the same layout,
+// so event time is determined by position of raw event shifted by offset of its group table from the first one.
+func setGroups(rawEvents []RawEvent, labels []groupLabel) {
+	if len(labels) == 0 {
+		return
+	}
+	for i := range rawEvents {
+		label := labels[0]
+		for _, l := range labels[1:] {
+			if l.x <= rawEvents[i].position.X {
+				label = l
+			}
+		}
+		rawEvents[i].group = label.name
+		rawEvents[i].offset = label.x - labels[0].x
+	}
+}
+
+// setBookmarkGroups sets group of raw events to group of the last bookmark pointing to their page or a preceding one,
+// e.g. "ИВТ-101" of bookmark "Группа ИВТ-101". Only bookmarks matching groupRegexp are used,
+// so outlines of other titles, e.g. "Расписание" or "Стр. 1", are ignored.
+// Groups of raw events of pages preceding bookmarks or containing several group labels,
+// which are set by coordinates of group labels, are kept.
+// Groups aren't set if content has no group bookmarks or page starts, or several group bookmarks point to the same page.
+func setBookmarkGroups(rawEvents []RawEvent, content *reader.Content) {
+	if len(content.PageStarts) == 0 {
+		return
+	}
+	bookmarks := make([]reader.Bookmark, 0, len(content.Bookmarks))
+	for _, bookmark := range content.Bookmarks {
+		if group := bookmarkGroup(bookmark.Title); group != "" {
+			bookmarks = append(bookmarks, reader.Bookmark{Title: group, Page: bookmark.Page})
+		}
+	}
+	if len(bookmarks) == 0 {
+		return
+	}
+	sort.SliceStable(bookmarks, func(i, j int) bool { return bookmarks[i].Page < bookmarks[j].Page })
+	for i := 1; i < len(bookmarks); i++ {
+		if bookmarks[i].Page == bookmarks[i-1].Page {
+			return
+		}
+	}
+	labels := pageGroupLabels(content)
+	for i := range rawEvents {
+		if labels[rawEvents[i].page] > 1 {
+			continue
+		}
+		for _, bookmark := range bookmarks {
+			if bookmark.Page <= rawEvents[i].page {
+				rawEvents[i].group = bookmark.Title
+				rawEvents[i].offset = 0
+			}
+		}
+	}
+}
+
+// pageGroupLabels returns number of group labels of each page of content by page number.
+func pageGroupLabels(content *reader.Content) map[int]int {
+	labels := make(map[int]int, len(content.PageStarts))
+	for i, start := range content.PageStarts {
+		end := len(content.Texts)
+		if i+1 < len(content.PageStarts) {
+			end = content.PageStarts[i+1].Index
+		}
+		labels[start.Number] += len(getGroupLabels(content.Texts[start.Index:end]))
+	}
+	return labels
+}
+
+// bookmarkGroup returns group of bookmark title matching groupRegexp or empty string if title doesn't match it.
+func bookmarkGroup(title string) string {
+	if submatches := groupRegexp.FindStringSubmatch(title); submatches != nil {
+		return submatches[1]
+	}
+	return ""
+}
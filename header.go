@@ -0,0 +1,174 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Coordinates of pdf content have origin at bottom-left corner of page, so larger Y is higher on page:
+// header has larger Y than events table, and rows of Monday have larger Y than rows of Tuesday.
+// Content with origin at top-left corner is converted by WithFlippedY.
+const (
+	// tableTop is Y coordinate of top of events table.
+	// Texts above it belong to header.
+	tableTop = 521
+	// tableLeft is X coordinate of left edge of events cells.
+	// Texts to the left of it belong to row labels.
+	tableLeft = 42
+)
+
+var (
+	facultyRegexp   = regexp.MustCompile(`(?i)^(?:факультет|институт)\s*:\s*(.+)$|^((?:факультет|институт)\s+.+)$`)
+	directionRegexp = regexp.MustCompile(`(?i)направлени[ея](?:\s+подготовки)?\s*:?\s*(.+)$`)
+	courseRegexp    = regexp.MustCompile(`(?i)(\d)\s*(?:-?й\s+)?курс|курс\s*:?\s*(\d)`)
+	// validityRegexp matches validity period of schedule, e.g. "действует с 01.09.2024 по 31.12.2024".
+	validityRegexp = regexp.MustCompile(`(?i)(?:действует|действительно|валидно?)\s+с\s+(\d{2}\.\d{2}\.\d{4})\s*(?:[-–—]|по)\s*(\d{2}\.\d{2}\.\d{4})`)
+	// semesterRegexp matches date range of semester, e.g. "семестр с 01.09 по 28.12" or "семестр 01.09.2000-28.12.2000".
+	semesterRegexp = regexp.MustCompile(`(?i)семестр\D*(\d{2}\.\d{2})(?:\.\d{2,4})?\s*(?:[-–—]|по)\s*(\d{2}\.\d{2})`)
+	// updatedRegexp matches last-updated date of schedule with optional time, e.g. "обновлено 05.09.2024 14:30".
+	updatedRegexp = regexp.MustCompile(`(?i)(?:обновлено|обновлён[оа]?|дата\s+обновления)\s*:?\s*(\d{2}\.\d{2}\.\d{4})(?:\s*(?:в\s*)?(\d{1,2}:\d{2}))?`)
+)
+
+// semester contains start and end dates of semester in dateFormat.
+type semester struct {
+	start string
+	end   string
+}
+
+// getSemester returns semester range found in header text or nil if there is no such range.
+// Validity period of schedule is used as semester range when header has no semester range and validity isn't nil.
+func getSemester(texts []pdf.Text, validity *validity) *semester {
+	for _, line := range getHeaderLines(texts) {
+		if submatches := semesterRegexp.FindStringSubmatch(line); submatches != nil && isDate(submatches[1]) && isDate(submatches[2]) {
+			return &semester{submatches[1], submatches[2]}
+		}
+	}
+	if validity != nil {
+		return &semester{validity.from.Format(dateFormat), validity.to.Format(dateFormat)}
+	}
+	return nil
+}
+
+// validity contains first and last dates of validity period of schedule at midnight.
+type validity struct {
+	from time.Time
+	to   time.Time
+}
+
+// getValidity returns validity period found in header text or nil if there is no such period.
+func getValidity(texts []pdf.Text) *validity {
+	const layout = "02.01.2006"
+	for _, line := range getHeaderLines(texts) {
+		submatches := validityRegexp.FindStringSubmatch(line)
+		if submatches == nil {
+			continue
+		}
+		from, err := time.ParseInLocation(layout, submatches[1], loc)
+		if err != nil {
+			continue
+		}
+		to, err := time.ParseInLocation(layout, submatches[2], loc)
+		if err != nil || to.Before(from) {
+			continue
+		}
+		return &validity{from, to}
+	}
+	return nil
+}
+
+// contains reports whether civil date of t is within validity period.
+func (v *validity) contains(t time.Time) bool {
+	day := days(t.In(loc))
+	return day >= days(v.from) && day <= days(v.to)
+}
+
+// getUpdatedAt returns last-updated datetime found in header or footer text or zero time if there is no such datetime.
+// Updated date without time is returned at midnight.
+func getUpdatedAt(texts []pdf.Text) time.Time {
+	for _, line := range getLines(texts) {
+		submatches := updatedRegexp.FindStringSubmatch(line)
+		if submatches == nil {
+			continue
+		}
+		value, layout := submatches[1], "02.01.2006"
+		if submatches[2] != "" {
+			value, layout = value+" "+submatches[2], "02.01.2006 15:04"
+		}
+		if updatedAt, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return updatedAt
+		}
+	}
+	return time.Time{}
+}
+
+// getHeaderLines takes slice of pdf.Text and returns lines of header text using getLines.
+func getHeaderLines(texts []pdf.Text) []string {
+	header := make([]pdf.Text, 0)
+	for _, text := range texts {
+		if text.Y >= tableTop {
+			header = append(header, text)
+		}
+	}
+	return getLines(header)
+}
+
+// getLines takes slice of pdf.Text and returns lines of text.
+// Consecutive texts with the same Y coordinate form a line.
+func getLines(texts []pdf.Text) []string {
+	lines := make([]string, 0)
+	var (
+		line string
+		y    float64
+	)
+	for _, text := range texts {
+		if line != "" && text.Y != y {
+			lines = append(lines, line)
+			line = ""
+		}
+		line += text.S
+		y = text.Y
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// firstGroup returns the first non-empty submatch of regexp.
+func firstGroup(submatches []string) string {
+	for _, submatch := range submatches[1:] {
+		if submatch != "" {
+			return submatch
+		}
+	}
+	return ""
+}
+
+// parseHeader parses faculty, direction and course from header text
+// and last-updated datetime from header or footer text and sets them to schedule along with validity period
+// found by getValidity. Fields that are not found stay empty.
+func parseHeader(texts []pdf.Text, validity *validity, schedule *Schedule) {
+	schedule.UpdatedAt = getUpdatedAt(texts)
+	if validity != nil {
+		schedule.ValidFrom, schedule.ValidTo = validity.from, validity.to
+	}
+	for _, line := range getHeaderLines(texts) {
+		if submatches := courseRegexp.FindStringSubmatch(line); submatches != nil && schedule.Course == 0 {
+			schedule.Course, _ = strconv.Atoi(firstGroup(submatches))
+			line = strings.Replace(line, submatches[0], "", 1)
+		}
+		line = strings.Trim(line, " ,;")
+
+		if submatches := facultyRegexp.FindStringSubmatch(line); submatches != nil && schedule.Faculty == "" {
+			schedule.Faculty = strings.Trim(firstGroup(submatches), " ,;")
+		} else if submatches := directionRegexp.FindStringSubmatch(line); submatches != nil && schedule.Direction == "" {
+			schedule.Direction = strings.Trim(firstGroup(submatches), " ,;")
+		}
+	}
+}
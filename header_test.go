@@ -0,0 +1,172 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+func Test_parseHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []pdf.Text
+		want  Schedule
+	}{
+		{
+			"Labeled",
+			[]pdf.Text{
+				{X: 300, Y: 570, S: "РАСПИСАНИЕ ЗАНЯТИЙ"},
+				{X: 40, Y: 555, S: "Факультет: информационных технологий"},
+				{X: 40, Y: 545, S: "Направление подготовки: 09.03.01 Информатика и вычислительная техника"},
+				{X: 40, Y: 535, S: "Курс: 2"},
+				{X: 46, Y: 500, S: "Title. лекции. Location. [05.09]"},
+			},
+			Schedule{Faculty: "информационных технологий", Direction: "09.03.01 Информатика и вычислительная техника", Course: 2},
+		},
+		{
+			"Inline",
+			[]pdf.Text{
+				{X: 40, Y: 555, S: "Факультет информационных технологий, 3 курс"},
+				{X: 40, Y: 545, S: "направление 09.03.04 Программная инженерия"},
+			},
+			Schedule{Faculty: "Факультет информационных технологий", Direction: "09.03.04 Программная инженерия", Course: 3},
+		},
+		{
+			"Validity",
+			[]pdf.Text{
+				{X: 300, Y: 570, S: "РАСПИСАНИЕ ЗАНЯТИЙ"},
+				{X: 40, Y: 555, S: "Расписание действует с 01.09.2024 по 31.12.2024"},
+			},
+			Schedule{ValidFrom: time.Date(2024, 9, 1, 0, 0, 0, 0, loc), ValidTo: time.Date(2024, 12, 31, 0, 0, 0, 0, loc)},
+		},
+		{
+			"Absent",
+			[]pdf.Text{
+				{X: 300, Y: 570, S: "РАСПИСАНИЕ ЗАНЯТИЙ"},
+				{X: 46, Y: 500, S: "Title. лекции. Курс 2. [05.09]"},
+			},
+			Schedule{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Schedule
+			parseHeader(tt.texts, getValidity(tt.texts), &got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseHeader() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getSemester(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want *semester
+	}{
+		{"Range", "Осенний семестр 01.09-28.12", &semester{"01.09", "28.12"}},
+		{"FromTo", "Семестр: с 01.09.2000 по 28.12.2000", &semester{"01.09", "28.12"}},
+		{"WithoutSemester", "Период 01.09-28.12", nil},
+		{"IncorrectDate", "Семестр 01.09-32.12", nil},
+		{"Validity", "Действует с 01.09.2024 по 31.12.2024", &semester{"01.09", "31.12"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			texts := []pdf.Text{{X: 40, Y: 555, S: tt.line}, {X: 46, Y: 500, S: "Title. лекции. Location. [05.09]"}}
+			if got := getSemester(texts, getValidity(texts)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSemester() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getUpdatedAt(t *testing.T) {
+	tests := []struct {
+		name string
+		text pdf.Text
+		want time.Time
+	}{
+		{"Header", pdf.Text{X: 600, Y: 555, S: "Обновлено 05.09.2024 14:30"}, time.Date(2024, 9, 5, 14, 30, 0, 0, loc)},
+		{"Footer", pdf.Text{X: 40, Y: 20, S: "Дата обновления: 05.09.2024"}, time.Date(2024, 9, 5, 0, 0, 0, 0, loc)},
+		{"WithoutUpdated", pdf.Text{X: 40, Y: 555, S: "Курс: 2"}, time.Time{}},
+		{"IncorrectDate", pdf.Text{X: 40, Y: 555, S: "обновлено 32.09.2024"}, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			texts := []pdf.Text{{X: 46, Y: 500, S: "Title. лекции. Location. [05.09]"}, tt.text}
+			if got := getUpdatedAt(texts); !got.Equal(tt.want) {
+				t.Errorf("getUpdatedAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_parseText_validity(t *testing.T) {
+	runes := func(texts ...pdf.Text) []pdf.Text {
+		result := make([]pdf.Text, 0)
+		for _, text := range texts {
+			for _, r := range text.S {
+				result = append(result, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+			}
+		}
+		return result
+	}
+	header := pdf.Text{X: 40, Y: 555, S: "Расписание действует с 01.09.2024 по 31.12.2024"}
+	texts := runes(
+		header,
+		pdf.Text{X: 5, Y: 500, S: "Вторник"},
+		pdf.Text{X: 46, Y: 500, S: "История. лекции. 101. [05.09]"},
+		pdf.Text{X: 139, Y: 500, S: "Физика. семинар. 202. [еженедельно]"},
+	)
+
+	schedule, err := NewParser().parseText(&reader.Content{Texts: texts}, time.Time{}, "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	if want := time.Date(2024, 9, 1, 0, 0, 0, 0, loc); !schedule.InitialDate.Equal(want) {
+		t.Errorf("Schedule.InitialDate = %v, want %v", schedule.InitialDate, want)
+	}
+	if start := schedule.Events[0].Dates[0].Start; start.Year() != 2024 {
+		t.Errorf("events[0] start = %v, want year %d", start, 2024)
+	}
+	weekly := schedule.Events[1].Dates[0]
+	if want := time.Date(2024, 9, 3, 10, 20, 0, 0, loc); !weekly.Start.Equal(want) {
+		t.Errorf("events[1] start = %v, want %v", weekly.Start, want)
+	}
+	if want := time.Date(2024, 12, 31, 12, 0, 0, 0, loc); !weekly.End.Equal(want) {
+		t.Errorf("events[1] end = %v, want %v", weekly.End, want)
+	}
+
+	outside := runes(header, pdf.Text{X: 46, Y: 500, S: "История. лекции. 101. [05.09]"})
+	var handled []error
+	handler := func(index int, raw RawEvent, err error) { handled = append(handled, err) }
+	schedule, err = NewParser(WithErrorHandler(handler)).parseText(&reader.Content{Texts: outside}, time.Date(2000, 8, 20, 0, 0, 0, 0, loc), "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	if len(schedule.Events) != 1 {
+		t.Errorf("len(Schedule.Events) = %d, want %d", len(schedule.Events), 1)
+	}
+	if len(handled) != 1 || !errors.Is(handled[0], ErrOutOfValidity) {
+		t.Errorf("handled errors = %v, want %v", handled, ErrOutOfValidity)
+	}
+}
+
+func TestSchedule_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Schedule{InitialDate: time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"initialDate":"2000-08-20T00:00:00Z","faculty":"","direction":"","course":0,"updatedAt":"0001-01-01T00:00:00Z","events":null}`
+	if string(data) != want {
+		t.Errorf("json.Marshal() = %s, want %s", data, want)
+	}
+}
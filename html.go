@@ -0,0 +1,111 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// gridWeekdays are columns of grid written by WriteHTMLGrid.
+var gridWeekdays = [...]time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+// gridSlot is row of grid written by WriteHTMLGrid, i.e. clock times of start and end of event date in minutes.
+type gridSlot struct {
+	start, end int
+}
+
+// clockMinutes returns clock time of t in minutes.
+func clockMinutes(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
+
+// gridSlots returns distinct time slots of dates of events sorted by start and end.
+func gridSlots(events []Event) []gridSlot {
+	slots := make([]gridSlot, 0)
+	seen := make(map[gridSlot]bool)
+	for i := range events {
+		for _, date := range events[i].Dates {
+			slot := gridSlot{clockMinutes(date.Start), clockMinutes(date.End)}
+			if !seen[slot] {
+				seen[slot] = true
+				slots = append(slots, slot)
+			}
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		if slots[i].start != slots[j].start {
+			return slots[i].start < slots[j].start
+		}
+		return slots[i].end < slots[j].end
+	})
+	return slots
+}
+
+// WriteHTMLGrid writes events to w as html table with time slots as rows and weekdays as columns.
+// Time slots are taken from start and end times of dates of events, so that custom slots such as
+// ones of WithTimeSlots are kept. Event is placed to cell of weekday and time slot of each of its dates,
+// several events sharing cell are stacked. Cancelled events are written with "cancelled" class.
+// Sunday column is written only if some event falls on it.
+func WriteHTMLGrid(events []Event, w io.Writer) error {
+	slots := gridSlots(events)
+	rows := make(map[gridSlot]int, len(slots))
+	for i, slot := range slots {
+		rows[slot] = i
+	}
+	cells := make([][len(gridWeekdays)][]*Event, len(slots))
+	for i := range events {
+		event := &events[i]
+		for j := range event.Dates {
+			start := event.Dates[j].Start
+			slot, day := rows[gridSlot{clockMinutes(start), clockMinutes(event.Dates[j].End)}], (int(start.Weekday())+6)%7
+			cell := cells[slot][day]
+			if len(cell) == 0 || cell[len(cell)-1] != event {
+				cells[slot][day] = append(cell, event)
+			}
+		}
+	}
+	days := len(gridWeekdays) - 1
+	for slot := range cells {
+		if len(cells[slot][days]) > 0 {
+			days++
+			break
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "<table>")
+	fmt.Fprint(bw, "<tr><th></th>")
+	for _, weekday := range gridWeekdays[:days] {
+		fmt.Fprintf(bw, "<th>%s</th>", weekday)
+	}
+	fmt.Fprintln(bw, "</tr>")
+	for i, slot := range slots {
+		fmt.Fprintf(bw, "<tr><th>%02d:%02d-%02d:%02d</th>", slot.start/60, slot.start%60, slot.end/60, slot.end%60)
+		for _, cell := range cells[i][:days] {
+			fmt.Fprint(bw, "<td>")
+			for _, event := range cell {
+				class := "event"
+				if event.Cancelled {
+					class = "event cancelled"
+				}
+				details := make([]string, 0, 3)
+				for _, detail := range []string{event.Type, event.Teacher, event.Location} {
+					if detail != "" {
+						details = append(details, html.EscapeString(detail))
+					}
+				}
+				fmt.Fprintf(bw, `<div class="%s"><b>%s</b><br>%s</div>`, class, html.EscapeString(event.Title), strings.Join(details, ", "))
+			}
+			fmt.Fprint(bw, "</td>")
+		}
+		fmt.Fprintln(bw, "</tr>")
+	}
+	fmt.Fprintln(bw, "</table>")
+	return bw.Flush()
+}
@@ -0,0 +1,53 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteHTMLGrid(t *testing.T) {
+	events := []Event{
+		{Title: "First", Type: "lecture", Teacher: "Teacher T.T.", Location: "101", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 19, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+		{Title: "Second & Third", Type: "lab", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 12, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "Fourth", Type: "seminar", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 9, 14, 10, 0, 0, loc), End: time.Date(2000, 9, 9, 15, 50, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "Custom", Type: "lab", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 4, 9, 0, 0, 0, loc), End: time.Date(2000, 9, 4, 10, 0, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "Fifth", Cancelled: true, Dates: []EventDate{
+			{Start: time.Date(2000, 9, 6, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 6, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteHTMLGrid(events, &buf); err != nil {
+		t.Fatalf("WriteHTMLGrid() error = %v", err)
+	}
+	got := buf.String()
+	wants := []string{
+		"<tr><th></th><th>Monday</th><th>Tuesday</th><th>Wednesday</th><th>Thursday</th><th>Friday</th><th>Saturday</th></tr>\n",
+		"<tr><th>08:30-10:10</th><td></td>" +
+			`<td><div class="event"><b>First</b><br>lecture, Teacher T.T., 101</div><div class="event"><b>Second &amp; Third</b><br>lab</div></td>` +
+			`<td><div class="event cancelled"><b>Fifth</b><br></div></td>`,
+		"</tr>\n<tr><th>09:00-10:00</th>" + `<td><div class="event"><b>Custom</b><br>lab</div></td><td></td>`,
+		"<tr><th>14:10-15:50</th><td></td><td></td><td></td><td></td><td></td>" +
+			`<td><div class="event"><b>Fourth</b><br>seminar</div></td></tr>`,
+	}
+	for _, want := range wants {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteHTMLGrid() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "Sunday") {
+		t.Errorf("WriteHTMLGrid() = %q, want it without Sunday column", got)
+	}
+}
@@ -0,0 +1,211 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const icsTimeLayout = "20060102T150405Z"
+
+// icsReplacer escapes text values of iCalendar properties.
+var icsReplacer = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// writeICSLine writes iCalendar content line folded to lines of at most 75 octets.
+func writeICSLine(w *bufio.Writer, line string) {
+	const maxLength = 75
+	for len(line) > maxLength {
+		n := maxLength
+		for !utf8.RuneStart(line[n]) {
+			n--
+		}
+		w.WriteString(line[:n] + "\r\n")
+		line = " " + line[n:]
+	}
+	w.WriteString(line + "\r\n")
+}
+
+// icsRecurrence returns RRULE value of event date or empty string if it occurs once.
+func icsRecurrence(date *EventDate) string {
+	until := date.End.UTC().Format(icsTimeLayout)
+	switch date.Frequency {
+	case FrequencyEvery:
+		return "FREQ=WEEKLY;UNTIL=" + until
+	case FrequencyThroughout:
+		return "FREQ=WEEKLY;INTERVAL=2;UNTIL=" + until
+	case FrequencyContinuous:
+		return "FREQ=DAILY;UNTIL=" + until
+	}
+	return ""
+}
+
+// eventDetails returns non-empty type, teacher, subgroup and note of event
+// used in descriptions of calendar events.
+func eventDetails(event *Event) []string {
+	details := make([]string, 0, 4)
+	for _, detail := range []string{event.Type, event.Teacher, event.Subgroup, event.Note} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	return details
+}
+
+// icsOptions contains settings of WriteICS.
+type icsOptions struct {
+	clock          func() time.Time
+	maxOccurrences int
+}
+
+// ICSOption configures WriteICS, ExportICSBySubgroup and ExportICSByWeekday.
+type ICSOption func(*icsOptions)
+
+// WithICSClock sets function returning current time, which is used instead of time.Now as DTSTAMP of events.
+func WithICSClock(clock func() time.Time) ICSOption {
+	return func(o *icsOptions) {
+		o.clock = clock
+	}
+}
+
+// WithICSMaxOccurrences makes WriteICS return error wrapping ErrTooManyOccurrences without writing calendar
+// if any event occurs more than n times. Zero n means no limit.
+func WithICSMaxOccurrences(n int) ICSOption {
+	return func(o *icsOptions) {
+		o.maxOccurrences = n
+	}
+}
+
+// WriteICS writes events to w as iCalendar calendar.
+// Every event date becomes separate VEVENT starting at first occurrence
+// and recurring according to frequency of date except its exceptions. Cancelled events are skipped.
+func WriteICS(events []Event, w io.Writer, opts ...ICSOption) error {
+	o := icsOptions{clock: time.Now}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	for i := range events {
+		if events[i].Cancelled {
+			continue
+		}
+		if err := checkOccurrenceCount(&events[i], o.maxOccurrences); err != nil {
+			return err
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	stamp := o.clock().UTC().Format(icsTimeLayout)
+
+	writeICSLine(bw, "BEGIN:VCALENDAR")
+	writeICSLine(bw, "VERSION:2.0")
+	writeICSLine(bw, "PRODID:-//qsoulior//scheduleparser//EN")
+	for i := range events {
+		event := &events[i]
+		if event.Cancelled {
+			continue
+		}
+		id := ComputeID(*event)
+		details := eventDetails(event)
+		for j := range event.Dates {
+			date := &event.Dates[j]
+			intervals := date.intervals()
+			if len(intervals) == 0 {
+				continue
+			}
+			first := intervals[0]
+
+			writeICSLine(bw, "BEGIN:VEVENT")
+			writeICSLine(bw, fmt.Sprintf("UID:%s-%d@scheduleparser", id, j))
+			writeICSLine(bw, "DTSTAMP:"+stamp)
+			writeICSLine(bw, "DTSTART:"+first.Start.UTC().Format(icsTimeLayout))
+			writeICSLine(bw, "DTEND:"+first.End.UTC().Format(icsTimeLayout))
+			if rule := icsRecurrence(date); rule != "" {
+				writeICSLine(bw, "RRULE:"+rule)
+			}
+			if len(date.Exceptions) > 0 {
+				exceptions := make([]string, len(date.Exceptions))
+				for k, exception := range date.Exceptions {
+					exceptions[k] = exception.UTC().Format(icsTimeLayout)
+				}
+				writeICSLine(bw, "EXDATE:"+strings.Join(exceptions, ","))
+			}
+			writeICSLine(bw, "SUMMARY:"+icsReplacer.Replace(event.Title))
+			if event.Location != "" {
+				writeICSLine(bw, "LOCATION:"+icsReplacer.Replace(event.Location))
+			}
+			if len(details) > 0 {
+				writeICSLine(bw, "DESCRIPTION:"+icsReplacer.Replace(strings.Join(details, "\n")))
+			}
+			writeICSLine(bw, "END:VEVENT")
+		}
+	}
+	writeICSLine(bw, "END:VCALENDAR")
+	return bw.Flush()
+}
+
+// ExportICSBySubgroup writes separate iCalendar calendar for every subgroup of events
+// and returns calendars by subgroup. Events without subgroup are included in every calendar.
+// If no event has subgroup, all events are written to single calendar with empty subgroup.
+func ExportICSBySubgroup(events []Event, opts ...ICSOption) (map[string][]byte, error) {
+	common := make([]Event, 0)
+	subgroups := make(map[string][]Event)
+	for _, event := range events {
+		if event.Subgroup == "" {
+			common = append(common, event)
+		} else {
+			subgroups[event.Subgroup] = append(subgroups[event.Subgroup], event)
+		}
+	}
+	if len(subgroups) == 0 {
+		subgroups[""] = nil
+	}
+
+	calendars := make(map[string][]byte, len(subgroups))
+	for subgroup, subgroupEvents := range subgroups {
+		var buf bytes.Buffer
+		if err := WriteICS(append(append([]Event{}, common...), subgroupEvents...), &buf, opts...); err != nil {
+			return nil, fmt.Errorf("subgroup %q: %w", subgroup, err)
+		}
+		calendars[subgroup] = buf.Bytes()
+	}
+	return calendars, nil
+}
+
+// ExportICSByWeekday writes separate iCalendar calendar for every weekday of events
+// and returns calendars by weekday, e.g. to share Monday classes only.
+// Event whose dates fall on several weekdays is included in calendar of each weekday with its dates on that weekday,
+// and continuous dates, such as practice blocks, recur weekly in calendar of each weekday they cover.
+func ExportICSByWeekday(events []Event, opts ...ICSOption) (map[time.Weekday][]byte, error) {
+	weekdays := make(map[time.Weekday][]Event)
+	for _, event := range events {
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			dates := make([]EventDate, 0)
+			for _, date := range event.Dates {
+				if date, ok := date.onWeekday(weekday); ok {
+					dates = append(dates, date)
+				}
+			}
+			if len(dates) > 0 {
+				weekdayEvent := event.Clone()
+				weekdayEvent.Dates = dates
+				weekdays[weekday] = append(weekdays[weekday], weekdayEvent)
+			}
+		}
+	}
+
+	calendars := make(map[time.Weekday][]byte, len(weekdays))
+	for weekday, weekdayEvents := range weekdays {
+		var buf bytes.Buffer
+		if err := WriteICS(weekdayEvents, &buf, opts...); err != nil {
+			return nil, fmt.Errorf("weekday %s: %w", weekday, err)
+		}
+		calendars[weekday] = buf.Bytes()
+	}
+	return calendars, nil
+}
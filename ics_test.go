@@ -0,0 +1,165 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteICS(t *testing.T) {
+	clock := func() time.Time { return time.Date(2000, 8, 20, 0, 0, 0, 0, loc) }
+
+	events := []Event{
+		{Title: "Title, part", Teacher: "Teacher T.T.", Type: "lecture", Location: "101", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyThroughout},
+		}},
+		{Title: "Cancelled", Cancelled: true, Dates: []EventDate{
+			{Start: time.Date(2000, 9, 6, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 6, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+	want := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"PRODID:-//qsoulior//scheduleparser//EN\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:" + ComputeID(events[0]) + "-0@scheduleparser\r\n" +
+		"DTSTAMP:20000819T210000Z\r\n" +
+		"DTSTART:20000905T053000Z\r\n" +
+		"DTEND:20000905T071000Z\r\n" +
+		"RRULE:FREQ=WEEKLY;INTERVAL=2;UNTIL=20001205T071000Z\r\n" +
+		"SUMMARY:Title\\, part\r\n" +
+		"LOCATION:101\r\n" +
+		"DESCRIPTION:lecture\\nTeacher T.T.\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	var buf bytes.Buffer
+	if err := WriteICS(events, &buf, WithICSClock(clock)); err != nil {
+		t.Fatalf("WriteICS() error = %v", err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("WriteICS() = %q, want %q", got, want)
+	}
+}
+
+func TestWriteICS_exceptions(t *testing.T) {
+	events := []Event{
+		{Title: "Title", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery, Exceptions: []time.Time{
+				time.Date(2000, 10, 10, 8, 30, 0, 0, loc), time.Date(2000, 10, 17, 8, 30, 0, 0, loc),
+			}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteICS(events, &buf); err != nil {
+		t.Fatalf("WriteICS() error = %v", err)
+	}
+	want := "RRULE:FREQ=WEEKLY;UNTIL=20001205T071000Z\r\nEXDATE:20001010T053000Z,20001017T053000Z\r\n"
+	if got := buf.String(); !strings.Contains(got, want) {
+		t.Errorf("WriteICS() = %q, want to contain %q", got, want)
+	}
+}
+
+func TestWithICSMaxOccurrences(t *testing.T) {
+	events := []Event{
+		{Title: "Title", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteICS(events, &buf, WithICSMaxOccurrences(13)); !errors.Is(err, ErrTooManyOccurrences) {
+		t.Errorf("WriteICS() error = %v, want %v", err, ErrTooManyOccurrences)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("WriteICS() = %q, want empty", buf.String())
+	}
+	if err := WriteICS(events, &buf, WithICSMaxOccurrences(14)); err != nil {
+		t.Errorf("WriteICS() error = %v", err)
+	}
+}
+
+func TestExportICSBySubgroup(t *testing.T) {
+	date := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce}
+	events := []Event{
+		{Title: "Common", Type: "lecture", Dates: []EventDate{date}},
+		{Title: "First", Type: "lab", Subgroup: "1", Dates: []EventDate{date}},
+		{Title: "Second", Type: "lab", Subgroup: "2", Dates: []EventDate{date}},
+	}
+
+	calendars, err := ExportICSBySubgroup(events)
+	if err != nil {
+		t.Fatalf("ExportICSBySubgroup() error = %v", err)
+	}
+	if len(calendars) != 2 {
+		t.Fatalf("len(ExportICSBySubgroup()) = %d, want %d", len(calendars), 2)
+	}
+	for subgroup, titles := range map[string][2]string{"1": {"First", "Second"}, "2": {"Second", "First"}} {
+		calendar := string(calendars[subgroup])
+		if !strings.Contains(calendar, "SUMMARY:Common\r\n") {
+			t.Errorf("calendar of subgroup %q doesn't contain common event", subgroup)
+		}
+		if !strings.Contains(calendar, "SUMMARY:"+titles[0]+"\r\n") {
+			t.Errorf("calendar of subgroup %q doesn't contain event %q", subgroup, titles[0])
+		}
+		if strings.Contains(calendar, "SUMMARY:"+titles[1]+"\r\n") {
+			t.Errorf("calendar of subgroup %q contains event %q", subgroup, titles[1])
+		}
+	}
+}
+
+func TestExportICSByWeekday(t *testing.T) {
+	monday := EventDate{Start: time.Date(2000, 9, 4, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 4, 10, 10, 0, 0, loc), Frequency: FrequencyEvery}
+	tuesday := EventDate{Start: time.Date(2000, 9, 5, 12, 20, 0, 0, loc), End: time.Date(2000, 9, 5, 14, 0, 0, 0, loc), Frequency: FrequencyOnce}
+	practice := EventDate{Start: time.Date(2000, 9, 6, 9, 0, 0, 0, loc), End: time.Date(2000, 9, 19, 17, 0, 0, 0, loc), Frequency: FrequencyContinuous}
+	events := []Event{
+		{Title: "Monday", Type: "lecture", Dates: []EventDate{monday}},
+		{Title: "Tuesday", Type: "seminar", Dates: []EventDate{tuesday}},
+		{Title: "Both", Type: "seminar", Dates: []EventDate{monday, tuesday}},
+		{Title: "Practice", Dates: []EventDate{practice}},
+	}
+
+	calendars, err := ExportICSByWeekday(events)
+	if err != nil {
+		t.Fatalf("ExportICSByWeekday() error = %v", err)
+	}
+	if len(calendars) != 7 {
+		t.Fatalf("len(ExportICSByWeekday()) = %d, want %d", len(calendars), 7)
+	}
+	got := string(calendars[time.Monday])
+	for _, want := range []string{
+		"SUMMARY:Monday\r\n",
+		"SUMMARY:Both\r\n",
+		"DTSTART:20000911T060000Z\r\nDTEND:20000911T140000Z\r\nRRULE:FREQ=WEEKLY;UNTIL=20000918T140000Z\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Monday calendar = %q, want to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "SUMMARY:Tuesday") || strings.Contains(got, "DTSTART:20000905") {
+		t.Errorf("Monday calendar = %q, want no Tuesday events", got)
+	}
+	if strings.Count(got, "BEGIN:VEVENT") != 3 {
+		t.Errorf("Monday calendar has %d events, want %d", strings.Count(got, "BEGIN:VEVENT"), 3)
+	}
+}
+
+func Test_writeICSLine(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeICSLine(w, "SUMMARY:"+strings.Repeat("я", 40))
+	w.Flush()
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line %q is longer than 75 octets", line)
+		}
+	}
+	if got := strings.ReplaceAll(buf.String(), "\r\n ", ""); got != "SUMMARY:"+strings.Repeat("я", 40)+"\r\n" {
+		t.Errorf("unfolded line = %q", got)
+	}
+}
@@ -0,0 +1,185 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// idOptions contains settings of ComputeID.
+type idOptions struct {
+	stripDegrees bool
+}
+
+// IDOption configures ComputeID and functions using it, such as ToMap.
+type IDOption func(*idOptions)
+
+// WithoutDegrees makes ComputeID ignore academic degree and position prefixes of teacher,
+// such as "доц." or "проф., д.т.н.", so that teacher written with and without them yields the same ID.
+// Teacher of event itself is kept unchanged.
+func WithoutDegrees() IDOption {
+	return func(o *idOptions) {
+		o.stripDegrees = true
+	}
+}
+
+// degreeRegexp matches academic degree and position prefixes of teacher.
+var degreeRegexp = regexp.MustCompile(`^(?:(?:доц|проф|ст\.\s*преп|преп|асс|акад|[кд]\.(?:[а-я]{1,4}\.-?)*[а-я]{1,4})\.[,\s]*)+`)
+
+// stripDegrees returns teacher without academic degree and position prefixes.
+func stripDegrees(teacher string) string {
+	return strings.TrimSpace(degreeRegexp.ReplaceAllString(strings.TrimSpace(teacher), ""))
+}
+
+// ComputeID returns stable identifier of event.
+// It is computed from title, teacher, type, subgroup and dates,
+// so that changes of event details such as location keep the same ID.
+func ComputeID(event Event, opts ...IDOption) string {
+	var o idOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	teacher := event.Teacher
+	if o.stripDegrees {
+		teacher = stripDegrees(teacher)
+	}
+
+	hash := sha1.New()
+	for _, field := range []string{event.Title, teacher, event.Type, event.Subgroup} {
+		hash.Write([]byte(field))
+		hash.Write([]byte{0})
+	}
+	for _, date := range event.Dates {
+		hash.Write([]byte(date.Start.Format(time.RFC3339)))
+		hash.Write([]byte(date.End.Format(time.RFC3339)))
+		hash.Write([]byte(date.Frequency))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ToMap returns events keyed by ComputeID, e.g. for upsert into database,
+// and number of collisions, i.e. events whose ID is already taken by preceding event.
+// The first of events with equal ID is kept.
+func ToMap(events []Event, opts ...IDOption) (map[string]Event, int) {
+	keyed := make(map[string]Event, len(events))
+	collisions := 0
+	for _, event := range events {
+		id := ComputeID(event, opts...)
+		if _, ok := keyed[id]; ok {
+			collisions++
+			continue
+		}
+		keyed[id] = event.Clone()
+	}
+	return keyed, collisions
+}
+
+// AssertUniqueIDs returns *IDCollisionError for the first ID shared by events that are not true duplicates,
+// i.e. that aren't Equal because they differ in fields ComputeID ignores, such as location.
+// It is intended for tests and debugging to surface fields missed by ComputeID, since ToMap keeps only one of such events.
+func AssertUniqueIDs(events []Event, opts ...IDOption) error {
+	ids := make([]string, 0)
+	byID := make(map[string][]Event)
+	for _, event := range events {
+		id := ComputeID(event, opts...)
+		distinct := true
+		for _, other := range byID[id] {
+			if event.Equal(other) {
+				distinct = false
+				break
+			}
+		}
+		if !distinct {
+			continue
+		}
+		if byID[id] == nil {
+			ids = append(ids, id)
+		}
+		byID[id] = append(byID[id], event)
+	}
+	for _, id := range ids {
+		if len(byID[id]) > 1 {
+			return &IDCollisionError{ID: id, Events: byID[id]}
+		}
+	}
+	return nil
+}
+
+// duplicateOptions contains settings of Deduplicate and MergeSchedules.
+type duplicateOptions struct {
+	byID   bool
+	idOpts []IDOption
+	equal  func(a, b Event) bool
+}
+
+// DuplicateOption configures functions telling duplicate events, such as Deduplicate and MergeSchedules.
+type DuplicateOption func(*duplicateOptions)
+
+// WithIDOptions makes Deduplicate and MergeSchedules consider events duplicates when their ComputeID with opts,
+// e.g. WithoutDegrees, is equal instead of comparing events by Equal, so that events differing
+// only in details such as location are duplicates. It has no effect when WithComparator is set.
+func WithIDOptions(opts ...IDOption) DuplicateOption {
+	return func(o *duplicateOptions) {
+		o.byID = true
+		o.idOpts = append(o.idOpts, opts...)
+	}
+}
+
+// WithComparator makes Deduplicate and MergeSchedules consider events duplicates when equal reports true for them,
+// e.g. to ignore teacher or location with Event.EqualIgnoring, instead of comparing events by Equal.
+func WithComparator(equal func(a, b Event) bool) DuplicateOption {
+	return func(o *duplicateOptions) {
+		o.equal = equal
+	}
+}
+
+// duplicates finds events added before that are duplicates of event
+// by comparator, or by ComputeID if comparator is nil.
+type duplicates struct {
+	opts   []IDOption
+	equal  func(a, b Event) bool
+	ids    map[string]int
+	events []Event
+	index  []int
+}
+
+// newDuplicates creates duplicates with options and returns *duplicates.
+func newDuplicates(opts []DuplicateOption) *duplicates {
+	var o duplicateOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.equal == nil && !o.byID {
+		o.equal = Event.Equal
+	}
+	return &duplicates{opts: o.idOpts, equal: o.equal, ids: make(map[string]int)}
+}
+
+// find returns index of event added before that is duplicate of event, and false if there is none.
+func (d *duplicates) find(event Event) (int, bool) {
+	if d.equal == nil {
+		i, ok := d.ids[ComputeID(event, d.opts...)]
+		return i, ok
+	}
+	for i := range d.events {
+		if d.equal(d.events[i], event) {
+			return d.index[i], true
+		}
+	}
+	return 0, false
+}
+
+// add adds event with given index.
+func (d *duplicates) add(event Event, index int) {
+	if d.equal == nil {
+		d.ids[ComputeID(event, d.opts...)] = index
+		return
+	}
+	d.events = append(d.events, event)
+	d.index = append(d.index, index)
+}
@@ -0,0 +1,104 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestComputeID(t *testing.T) {
+	date := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery}
+	event := Event{Title: "Title", Teacher: "Teacher T.T.", Type: "lecture", Location: "Location", Dates: []EventDate{date}}
+
+	relocated := event
+	relocated.Location = "Other"
+	if ComputeID(event) != ComputeID(relocated) {
+		t.Errorf("ComputeID() differs for events with different locations")
+	}
+
+	retitled := event
+	retitled.Title = "Other"
+	if ComputeID(event) == ComputeID(retitled) {
+		t.Errorf("ComputeID() is equal for events with different titles")
+	}
+
+	rescheduled := event
+	rescheduled.Dates = []EventDate{{Start: date.Start.AddDate(0, 0, 7), End: date.End, Frequency: FrequencyEvery}}
+	if ComputeID(event) == ComputeID(rescheduled) {
+		t.Errorf("ComputeID() is equal for events with different dates")
+	}
+}
+
+func TestWithoutDegrees(t *testing.T) {
+	date := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery}
+	event := Event{Title: "Title", Teacher: "Иванов И.И.", Type: "lecture", Dates: []EventDate{date}}
+
+	tests := []struct {
+		name    string
+		teacher string
+	}{
+		{"Docent", "доц. Иванов И.И."},
+		{"Professor", "проф. Иванов И.И."},
+		{"SeniorLecturer", "ст. преп. Иванов И.И."},
+		{"DegreeAndPosition", "доц., к.т.н. Иванов И.И."},
+		{"CompoundDegree", "д.ф.-м.н. Иванов И.И."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefixed := event
+			prefixed.Teacher = tt.teacher
+			if ComputeID(event) == ComputeID(prefixed) {
+				t.Errorf("ComputeID() is equal without WithoutDegrees for teacher %q", tt.teacher)
+			}
+			if ComputeID(event, WithoutDegrees()) != ComputeID(prefixed, WithoutDegrees()) {
+				t.Errorf("ComputeID(WithoutDegrees()) differs for teacher %q", tt.teacher)
+			}
+		})
+	}
+}
+
+func TestToMap(t *testing.T) {
+	date := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery}
+	events := []Event{
+		{Title: "Lecture", Teacher: "Teacher T.T.", Type: "lecture", Location: "101", Dates: []EventDate{date}},
+		{Title: "Seminar", Teacher: "Teacher T.T.", Type: "seminar", Location: "102", Dates: []EventDate{date}},
+		{Title: "Lecture", Teacher: "Teacher T.T.", Type: "lecture", Location: "201", Dates: []EventDate{date}},
+	}
+
+	got, collisions := ToMap(events)
+	if collisions != 1 {
+		t.Errorf("ToMap() collisions = %d, want %d", collisions, 1)
+	}
+	want := map[string]Event{
+		ComputeID(events[0]): events[0],
+		ComputeID(events[1]): events[1],
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToMap() = %v, want %v", got, want)
+	}
+}
+
+func TestAssertUniqueIDs(t *testing.T) {
+	date := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery}
+	event := Event{Title: "Lecture", Teacher: "Teacher T.T.", Type: "lecture", Location: "101", Dates: []EventDate{date}}
+	other := Event{Title: "Seminar", Teacher: "Teacher T.T.", Type: "seminar", Location: "102", Dates: []EventDate{date}}
+
+	if err := AssertUniqueIDs([]Event{event, other, event}); err != nil {
+		t.Errorf("AssertUniqueIDs() error = %v for true duplicates", err)
+	}
+
+	// Location is omitted from ID, so events differing only in it collide.
+	relocated := event
+	relocated.Location = "201"
+	err := AssertUniqueIDs([]Event{event, other, relocated})
+	var collision *IDCollisionError
+	if !errors.As(err, &collision) {
+		t.Fatalf("AssertUniqueIDs() error = %v, want *IDCollisionError", err)
+	}
+	if collision.ID != ComputeID(event) || !reflect.DeepEqual(collision.Events, []Event{event, relocated}) {
+		t.Errorf("IDCollisionError = %+v, want events %v with ID %s", collision, []Event{event, relocated}, ComputeID(event))
+	}
+}
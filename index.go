@@ -0,0 +1,83 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"sort"
+	"time"
+)
+
+// ScheduleIndex provides lookups of events by teacher, room and date.
+// Recurrences of event dates are expanded once by BuildIndex.
+type ScheduleIndex struct {
+	events    []Event
+	byTeacher map[string][]int
+	byRoom    map[string][]int
+	byDate    map[int][]indexedOccurrence
+}
+
+// indexedOccurrence is occurrence of event at index.
+type indexedOccurrence struct {
+	Occurrence
+	index int
+}
+
+// BuildIndex builds ScheduleIndex of events.
+// Event with several comma-separated teachers is indexed by each of them.
+func BuildIndex(events []Event) *ScheduleIndex {
+	index := &ScheduleIndex{
+		events:    events,
+		byTeacher: make(map[string][]int),
+		byRoom:    make(map[string][]int),
+		byDate:    make(map[int][]indexedOccurrence),
+	}
+	for i := range events {
+		event := &events[i]
+		for _, teacher := range splitTeachers(event.Teacher) {
+			index.byTeacher[teacher] = append(index.byTeacher[teacher], i)
+		}
+		if event.Location != "" {
+			room := CanonicalLocation(event.Location)
+			index.byRoom[room] = append(index.byRoom[room], i)
+		}
+		for _, occurrence := range Occurrences(*event) {
+			day := days(occurrence.Date)
+			index.byDate[day] = append(index.byDate[day], indexedOccurrence{occurrence, i})
+		}
+	}
+	for _, occurrences := range index.byDate {
+		sort.SliceStable(occurrences, func(i, j int) bool { return occurrences[i].Start.Before(occurrences[j].Start) })
+	}
+	return index
+}
+
+// eventsAt returns events at indexes.
+func (index *ScheduleIndex) eventsAt(indexes []int) []Event {
+	events := make([]Event, len(indexes))
+	for i, j := range indexes {
+		events[i] = index.events[j]
+	}
+	return events
+}
+
+// ByTeacher returns events of teacher in order of events passed to BuildIndex.
+func (index *ScheduleIndex) ByTeacher(teacher string) []Event {
+	return index.eventsAt(index.byTeacher[teacher])
+}
+
+// ByRoom returns events taking place in room in order of events passed to BuildIndex.
+// Rooms are compared by CanonicalLocation, so "101/А" and "корп. А, ауд. 101" are the same room.
+func (index *ScheduleIndex) ByRoom(room string) []Event {
+	return index.eventsAt(index.byRoom[CanonicalLocation(room)])
+}
+
+// ByDate returns events occurring on civil date of given date in order of their start time.
+// Event occurring several times on the date is returned for each occurrence.
+func (index *ScheduleIndex) ByDate(date time.Time) []Event {
+	occurrences := index.byDate[days(date)]
+	events := make([]Event, len(occurrences))
+	for i, occurrence := range occurrences {
+		events[i] = index.events[occurrence.index]
+	}
+	return events
+}
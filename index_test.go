@@ -0,0 +1,52 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScheduleIndex(t *testing.T) {
+	events := []Event{
+		{Title: "First", Teacher: "Иванов И.И.", Type: "lecture", Location: "101", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 10, 20, 0, 0, loc), End: time.Date(2000, 9, 19, 12, 0, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+		{Title: "Second", Teacher: "Петров П.П., Иванов И.И.", Type: "seminar", Location: "102", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "Third", Teacher: "Петров П.П.", Type: "lab", Location: "101", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 6, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 6, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+	index := BuildIndex(events)
+
+	titles := func(events []Event) []string {
+		titles := make([]string, len(events))
+		for i, event := range events {
+			titles[i] = event.Title
+		}
+		return titles
+	}
+	tests := []struct {
+		name string
+		got  []Event
+		want []string
+	}{
+		{"ByTeacher", index.ByTeacher("Иванов И.И."), []string{"First", "Second"}},
+		{"ByTeacherUnknown", index.ByTeacher("Сидоров С.С."), []string{}},
+		{"ByRoom", index.ByRoom("101"), []string{"First", "Third"}},
+		{"ByRoomCanonical", index.ByRoom("ауд. 101"), []string{"First", "Third"}},
+		{"ByDate", index.ByDate(time.Date(2000, 9, 5, 0, 0, 0, 0, loc)), []string{"Second", "First"}},
+		{"ByDateRecurrence", index.ByDate(time.Date(2000, 9, 19, 0, 0, 0, 0, loc)), []string{"First"}},
+		{"ByDateEmpty", index.ByDate(time.Date(2000, 9, 7, 0, 0, 0, 0, loc)), []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := titles(tt.got); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("titles = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+// RawEventDiag is raw event with result of its parsing.
+type RawEventDiag struct {
+	Raw RawEvent
+	// Err is error of parsing raw event, or nil if it parses.
+	Err error
+}
+
+// InspectRawEvents forms raw events from texts the same way as parsing does
+// and reports for each of them whether it parses, without returning events,
+// e.g. to highlight problem cells in correction tooling. Error handler of Parser isn't called.
+// It returns error only if raw events can't be formed, e.g. when limits of Parser are exceeded.
+func (p *Parser) InspectRawEvents(texts []pdf.Text, initialDate time.Time) ([]RawEventDiag, error) {
+	content := p.orient(&reader.Content{Texts: texts})
+	rawEvents, err := p.readRawEvents(content, p.initialDate(initialDate), getValidity(content.Texts))
+	if err != nil {
+		return nil, fmt.Errorf("reading events error: %w", err)
+	}
+	diags := make([]RawEventDiag, len(rawEvents))
+	for i := range rawEvents {
+		_, err := p.parseRawEvent(&rawEvents[i])
+		diags[i] = RawEventDiag{rawEvents[i], err}
+	}
+	return diags, nil
+}
+
+// EventTexts is event with texts of pdf content that form its cell.
+type EventTexts struct {
+	Event Event
+	Texts []pdf.Text
+}
+
+// ParseEventTexts parses events from texts the same way as parsing does and returns each of them
+// with texts forming its cell as RawEvent.Texts of WithTextRuns, e.g. to draw overlay of events in visual debugger.
+// Coordinates of texts are converted by WithFlippedY if Parser has it. Events are in order of cells,
+// so they aren't deduplicated or sorted. Failed raw events are handled as by parsing, i.e. passed to error handler of Parser.
+func (p *Parser) ParseEventTexts(texts []pdf.Text, initialDate time.Time) ([]EventTexts, error) {
+	debug := *p
+	debug.textRuns = true
+	content := debug.orient(&reader.Content{Texts: texts})
+	rawEvents, err := debug.readRawEvents(content, debug.initialDate(initialDate), getValidity(content.Texts))
+	if err != nil {
+		return nil, fmt.Errorf("reading events error: %w", err)
+	}
+	events := make([]EventTexts, 0, len(rawEvents))
+	for i := range rawEvents {
+		event, err := debug.parseRawEvent(&rawEvents[i])
+		if errors.Is(err, ErrOutOfValidity) {
+			if debug.errorHandler != nil {
+				debug.errorHandler(i, rawEvents[i], err)
+			}
+			events = append(events, EventTexts{*event, rawEvents[i].texts})
+			continue
+		}
+		if err != nil {
+			if debug.errorHandler != nil {
+				debug.errorHandler(i, rawEvents[i], err)
+				continue
+			}
+			if errors.Is(err, ErrLowConfidence) {
+				continue
+			}
+			return nil, fmt.Errorf("parsing error: parse events[%d]: %w", i, err)
+		}
+		events = append(events, EventTexts{*event, rawEvents[i].texts})
+	}
+	return events, nil
+}
+
+// ParseEventTexts creates Parser with options and uses its ParseEventTexts.
+func ParseEventTexts(texts []pdf.Text, initialDate time.Time, opts ...Option) ([]EventTexts, error) {
+	return NewParser(opts...).ParseEventTexts(texts, initialDate)
+}
+
+// InspectRawEvents creates Parser with options and uses its InspectRawEvents.
+func InspectRawEvents(texts []pdf.Text, initialDate time.Time, opts ...Option) ([]RawEventDiag, error) {
+	return NewParser(opts...).InspectRawEvents(texts, initialDate)
+}
@@ -0,0 +1,79 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestInspectRawEvents(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 500, S: "Valid. Teacher T.T. лекции. Location. [05.09]"},
+		{X: 139, Y: 500, S: "Invalid. Teacher T.T. Unknown. Location. [05.09]"},
+		{X: 233, Y: 500, S: "Garbage. Teacher T.T. семинар. Location. [ab.cd]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+
+	diags, err := InspectRawEvents(texts, time.Date(2000, 8, 20, 0, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatalf("InspectRawEvents() error = %v", err)
+	}
+	if len(diags) != 3 {
+		t.Fatalf("len(InspectRawEvents()) = %d, want %d", len(diags), 3)
+	}
+	wants := []struct {
+		title string
+		err   error
+	}{
+		{"Valid", nil},
+		{"Invalid", ErrTypeNotFound},
+		{"Garbage", ErrDateParse},
+	}
+	for i, want := range wants {
+		if !strings.HasPrefix(diags[i].Raw.Data(), want.title) {
+			t.Errorf("InspectRawEvents()[%d].Raw.Data() = %q, want prefix %q", i, diags[i].Raw.Data(), want.title)
+		}
+		if !errors.Is(diags[i].Err, want.err) {
+			t.Errorf("InspectRawEvents()[%d].Err = %v, want %v", i, diags[i].Err, want.err)
+		}
+	}
+
+	if _, err := InspectRawEvents(texts, time.Time{}, WithMaxEvents(1)); !errors.Is(err, ErrTooManyEvents) {
+		t.Errorf("InspectRawEvents() error = %v, want %v", err, ErrTooManyEvents)
+	}
+}
+
+func TestParseEventTexts(t *testing.T) {
+	texts := []pdf.Text{
+		{X: 46, Y: 500, S: "Title. Teacher T.T. лекции. Location. [05.09"},
+		{X: 120, Y: 500, S: "]"},
+		{X: 139, Y: 500, S: "Other. Teacher T.T. семинар. Location. [12.09"},
+		{X: 210, Y: 500, S: "]"},
+	}
+
+	events, err := ParseEventTexts(texts, time.Date(2000, 8, 20, 0, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatalf("ParseEventTexts() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("len(ParseEventTexts()) = %d, want %d", len(events), 2)
+	}
+	for i, title := range []string{"Title", "Other"} {
+		if events[i].Event.Title != title {
+			t.Errorf("events[%d].Title = %q, want %q", i, events[i].Event.Title, title)
+		}
+		if want := texts[2*i : 2*i+2]; !reflect.DeepEqual(events[i].Texts, want) {
+			t.Errorf("events[%d].Texts = %v, want %v", i, events[i].Texts, want)
+		}
+	}
+}
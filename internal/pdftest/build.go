@@ -0,0 +1,160 @@
+// Package pdftest provides functions for building pdf files in tests.
+
+package pdftest
+
+import (
+	"bytes"
+	"fmt"
+	"unicode/utf16"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Page contains texts and raw graphics operators of pdf page.
+type Page struct {
+	Texts []pdf.Text
+	// Graphics is content stream fragment written before texts, e.g. "1 0 0 rg 10 10 50 50 re f".
+	Graphics string
+	// Links are link annotations with URI actions.
+	Links []Link
+}
+
+// Link is link annotation of page.
+type Link struct {
+	Rect pdf.Rect
+	URI  string
+}
+
+// Bookmark is top-level outline entry pointing to page.
+type Bookmark struct {
+	Title string
+	Page  int // 1-based number of page
+}
+
+// Build returns bytes of pdf file whose pages contain given texts.
+func Build(pages ...[]pdf.Text) []byte {
+	contents := make([]Page, len(pages))
+	for i, texts := range pages {
+		contents[i] = Page{Texts: texts}
+	}
+	return BuildPages(contents...)
+}
+
+// BuildPages returns bytes of pdf file with given pages.
+// Texts are placed at their positions using font with ToUnicode map,
+// so pdf reader returns every rune of text as separate pdf.Text.
+// Only position and string of text are used.
+func BuildPages(pages ...Page) []byte {
+	return BuildOutline(nil, pages...)
+}
+
+// BuildOutline returns bytes of pdf file with given pages and document outline of bookmarks.
+// Bookmark titles are encoded in UTF-16 and point to pages by explicit destinations.
+func BuildOutline(bookmarks []Bookmark, pages ...Page) []byte {
+	codes := make(map[rune]byte)
+	runes := make([]rune, 0)
+	for _, page := range pages {
+		for _, text := range page.Texts {
+			for _, r := range text.S {
+				if _, ok := codes[r]; !ok {
+					if len(runes) == 255 {
+						panic("pdftest: too many distinct runes")
+					}
+					runes = append(runes, r)
+					codes[r] = byte(len(runes))
+				}
+			}
+		}
+	}
+
+	var cmap bytes.Buffer
+	fmt.Fprintf(&cmap, "begincmap\n1 begincodespacerange\n<00> <FF>\nendcodespacerange\n%d beginbfchar\n", len(runes))
+	for _, r := range runes {
+		fmt.Fprintf(&cmap, "<%02X> <", codes[r])
+		for _, u := range utf16.Encode([]rune{r}) {
+			fmt.Fprintf(&cmap, "%04X", u)
+		}
+		cmap.WriteString(">\n")
+	}
+	cmap.WriteString("endbfchar\nendcmap\n")
+
+	// Objects: 1 catalog, 2 pages, 3 font, 4 cmap, then page and content pairs.
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /ToUnicode 4 0 R >>",
+		stream(cmap.Bytes()),
+	}
+	kids := new(bytes.Buffer)
+	for _, page := range pages {
+		var content bytes.Buffer
+		if page.Graphics != "" {
+			content.WriteString(page.Graphics + "\n")
+		}
+		for _, text := range page.Texts {
+			fmt.Fprintf(&content, "BT /F1 8 Tf 1 0 0 1 %g %g Tm <", text.X, text.Y)
+			for _, r := range text.S {
+				fmt.Fprintf(&content, "%02X", codes[r])
+			}
+			content.WriteString("> Tj ET\n")
+		}
+		var annots bytes.Buffer
+		if len(page.Links) > 0 {
+			annots.WriteString(" /Annots [")
+			for _, link := range page.Links {
+				fmt.Fprintf(&annots, " << /Type /Annot /Subtype /Link /Rect [%g %g %g %g] /A << /S /URI /URI (%s) >> >>",
+					link.Rect.Min.X, link.Rect.Min.Y, link.Rect.Max.X, link.Rect.Max.Y, link.URI)
+			}
+			annots.WriteString(" ]")
+		}
+		pageIndex := len(objects) + 1
+		fmt.Fprintf(kids, "%d 0 R ", pageIndex)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 842 595] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R%s >>", pageIndex+1, annots.Bytes()),
+			stream(content.Bytes()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids.Bytes()), len(pages))
+	if len(bookmarks) > 0 {
+		outlines := len(objects) + 1
+		first, last := outlines+1, outlines+len(bookmarks)
+		objects[0] = fmt.Sprintf("<< /Type /Catalog /Pages 2 0 R /Outlines %d 0 R >>", outlines)
+		objects = append(objects, fmt.Sprintf("<< /Type /Outlines /First %d 0 R /Last %d 0 R /Count %d >>", first, last, len(bookmarks)))
+		for i, bookmark := range bookmarks {
+			var title bytes.Buffer
+			title.WriteString("FEFF")
+			for _, u := range utf16.Encode([]rune(bookmark.Title)) {
+				fmt.Fprintf(&title, "%04X", u)
+			}
+			// Page objects follow catalog, pages, font and cmap objects and alternate with content objects.
+			entry := fmt.Sprintf("<< /Title <%s> /Parent %d 0 R /Dest [%d 0 R /Fit]", title.Bytes(), outlines, 5+2*(bookmark.Page-1))
+			if i > 0 {
+				entry += fmt.Sprintf(" /Prev %d 0 R", first+i-1)
+			}
+			if i < len(bookmarks)-1 {
+				entry += fmt.Sprintf(" /Next %d 0 R", first+i+1)
+			}
+			objects = append(objects, entry+" >>")
+		}
+	}
+
+	var file bytes.Buffer
+	file.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = file.Len()
+		fmt.Fprintf(&file, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := file.Len()
+	fmt.Fprintf(&file, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&file, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&file, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return file.Bytes()
+}
+
+// stream returns pdf stream object with given data.
+func stream(data []byte) string {
+	return fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(data), data)
+}
@@ -0,0 +1,108 @@
+// Package reader provides functions for reading pdf files.
+
+package reader
+
+import "github.com/ledongthuc/pdf"
+
+// Fill is rectangle filled with color in pdf page.
+type Fill struct {
+	Rect pdf.Rect
+	// Color contains red, green and blue components in range [0, 1].
+	Color [3]float64
+	// Page is number of pdf page containing fill, or 0 if it is unknown.
+	Page int
+}
+
+// fillState is graphics state used to read fills.
+type fillState struct {
+	ctm   [6]float64
+	color [3]float64
+}
+
+// transform applies current transformation matrix to point.
+func (s *fillState) transform(x, y float64) pdf.Point {
+	m := s.ctm
+	return pdf.Point{X: m[0]*x + m[2]*y + m[4], Y: m[1]*x + m[3]*y + m[5]}
+}
+
+// fillColor converts gray, RGB or CMYK operands to RGB color.
+// It returns false if number of operands is not supported.
+func fillColor(args []float64) ([3]float64, bool) {
+	switch len(args) {
+	case 1:
+		return [3]float64{args[0], args[0], args[0]}, true
+	case 3:
+		return [3]float64{args[0], args[1], args[2]}, true
+	case 4:
+		c, m, y, k := args[0], args[1], args[2], args[3]
+		return [3]float64{(1 - c) * (1 - k), (1 - m) * (1 - k), (1 - y) * (1 - k)}, true
+	}
+	return [3]float64{}, false
+}
+
+// readFills interprets content stream of page and returns rectangles filled with color.
+// Only rectangles appended by "re" operator are taken into account,
+// other paths and form XObjects are ignored.
+func readFills(page pdf.Page) []Fill {
+	fills := make([]Fill, 0)
+	state := fillState{ctm: [6]float64{1, 0, 0, 1, 0, 0}}
+	stack := make([]fillState, 0)
+	path := make([]pdf.Rect, 0)
+
+	pdf.Interpret(page.V.Key("Contents"), func(stk *pdf.Stack, op string) {
+		// Non-numeric operands (e.g. pattern names) make operator ignored.
+		args := make([]float64, stk.Len())
+		numeric := true
+		for i := len(args) - 1; i >= 0; i-- {
+			v := stk.Pop()
+			if kind := v.Kind(); kind != pdf.Integer && kind != pdf.Real {
+				numeric = false
+			}
+			args[i] = v.Float64()
+		}
+		if !numeric {
+			args = nil
+		}
+		switch op {
+		case "q":
+			stack = append(stack, state)
+		case "Q":
+			if n := len(stack); n > 0 {
+				state, stack = stack[n-1], stack[:n-1]
+			}
+		case "cm":
+			if len(args) == 6 {
+				m := state.ctm
+				state.ctm = [6]float64{
+					args[0]*m[0] + args[1]*m[2], args[0]*m[1] + args[1]*m[3],
+					args[2]*m[0] + args[3]*m[2], args[2]*m[1] + args[3]*m[3],
+					args[4]*m[0] + args[5]*m[2] + m[4], args[4]*m[1] + args[5]*m[3] + m[5],
+				}
+			}
+		case "g", "rg", "k", "sc", "scn":
+			if color, ok := fillColor(args); ok {
+				state.color = color
+			}
+		case "re":
+			if len(args) == 4 {
+				p0 := state.transform(args[0], args[1])
+				p1 := state.transform(args[0]+args[2], args[1]+args[3])
+				if p0.X > p1.X {
+					p0.X, p1.X = p1.X, p0.X
+				}
+				if p0.Y > p1.Y {
+					p0.Y, p1.Y = p1.Y, p0.Y
+				}
+				path = append(path, pdf.Rect{Min: p0, Max: p1})
+			}
+		case "f", "F", "f*", "B", "B*", "b", "b*":
+			for _, rect := range path {
+				fills = append(fills, Fill{Rect: rect, Color: state.color})
+			}
+			path = path[:0]
+		case "n", "S", "s":
+			path = path[:0]
+		}
+	})
+	return fills
+}
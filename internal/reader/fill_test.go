@@ -0,0 +1,46 @@
+// Package reader provides functions for reading pdf files.
+
+package reader
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/pdftest"
+)
+
+func TestReadContent_fills(t *testing.T) {
+	content := pdftest.BuildPages(pdftest.Page{
+		Texts: []pdf.Text{{X: 46, Y: 500, S: "Title"}},
+		Graphics: "q 1 1 0 rg 40 480 90 30 re f Q " +
+			"0.5 g 0 0 10 10 re S " +
+			"q 1 0 0 1 100 100 cm 0 0 1 0 k 0 0 20 10 re f Q " +
+			"/P0 scn 200 200 5 5 re f",
+	})
+
+	got, err := ReadContent(bytes.NewReader(content), int64(len(content)), Options{Fills: true})
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+	want := []Fill{
+		{pdf.Rect{Min: pdf.Point{X: 40, Y: 480}, Max: pdf.Point{X: 130, Y: 510}}, [3]float64{1, 1, 0}, 1},
+		{pdf.Rect{Min: pdf.Point{X: 100, Y: 100}, Max: pdf.Point{X: 120, Y: 110}}, [3]float64{1, 1, 0}, 1},
+		{pdf.Rect{Min: pdf.Point{X: 200, Y: 200}, Max: pdf.Point{X: 205, Y: 205}}, [3]float64{0.5, 0.5, 0.5}, 1},
+	}
+	if !reflect.DeepEqual(got.Fills, want) {
+		t.Errorf("ReadContent().Fills = %v, want %v", got.Fills, want)
+	}
+	if len(got.Texts) != 5 {
+		t.Errorf("len(ReadContent().Texts) = %d, want %d", len(got.Texts), 5)
+	}
+
+	got, err = ReadContent(bytes.NewReader(content), int64(len(content)), Options{})
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+	if got.Fills != nil {
+		t.Errorf("ReadContent().Fills = %v, want nil", got.Fills)
+	}
+}
@@ -0,0 +1,43 @@
+// Package reader provides functions for reading pdf files.
+
+package reader
+
+import (
+	"math"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// Link is area of pdf page annotated with link to URI.
+type Link struct {
+	Rect pdf.Rect
+	URI  string
+	Page int // number of pdf page containing link, or 0 if it is unknown
+}
+
+// readLinks returns link annotations of page with URI actions.
+// Other annotations and link actions, e.g. links to pages of the same file, are ignored.
+func readLinks(page pdf.Page) []Link {
+	links := make([]Link, 0)
+	annots := page.V.Key("Annots")
+	for i := 0; i < annots.Len(); i++ {
+		annot := annots.Index(i)
+		action := annot.Key("A")
+		if annot.Key("Subtype").Name() != "Link" || action.Key("S").Name() != "URI" {
+			continue
+		}
+		rect := annot.Key("Rect")
+		if rect.Len() != 4 {
+			continue
+		}
+		x0, y0, x1, y1 := rect.Index(0).Float64(), rect.Index(1).Float64(), rect.Index(2).Float64(), rect.Index(3).Float64()
+		links = append(links, Link{
+			Rect: pdf.Rect{
+				Min: pdf.Point{X: math.Min(x0, x1), Y: math.Min(y0, y1)},
+				Max: pdf.Point{X: math.Max(x0, x1), Y: math.Max(y0, y1)},
+			},
+			URI: action.Key("URI").RawString(),
+		})
+	}
+	return links
+}
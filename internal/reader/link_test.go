@@ -0,0 +1,38 @@
+// Package reader provides functions for reading pdf files.
+
+package reader
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/pdftest"
+)
+
+func TestReadContent_links(t *testing.T) {
+	content := pdftest.BuildPages(pdftest.Page{
+		Texts: []pdf.Text{{X: 46, Y: 500, S: "Title"}},
+		Links: []pdftest.Link{
+			{Rect: pdf.Rect{Min: pdf.Point{X: 46, Y: 480}, Max: pdf.Point{X: 130, Y: 490}}, URI: "https://meet.example.com/abc"},
+		},
+	})
+
+	got, err := ReadContent(bytes.NewReader(content), int64(len(content)), Options{Links: true})
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+	want := []Link{{pdf.Rect{Min: pdf.Point{X: 46, Y: 480}, Max: pdf.Point{X: 130, Y: 490}}, "https://meet.example.com/abc", 1}}
+	if !reflect.DeepEqual(got.Links, want) {
+		t.Errorf("ReadContent().Links = %v, want %v", got.Links, want)
+	}
+
+	got, err = ReadContent(bytes.NewReader(content), int64(len(content)), Options{})
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+	if got.Links != nil {
+		t.Errorf("ReadContent().Links = %v, want nil", got.Links)
+	}
+}
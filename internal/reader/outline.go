@@ -0,0 +1,35 @@
+// Package reader provides functions for reading pdf files.
+
+package reader
+
+import "github.com/ledongthuc/pdf"
+
+// Bookmark is top-level entry of document outline pointing to pdf page, e.g. page of student group.
+type Bookmark struct {
+	Title string
+	Page  int // 1-based number of page
+}
+
+// readBookmarks returns top-level entries of document outline with explicit page destinations in order of outline.
+// Entries with named destinations or without destination are skipped.
+// Pages are told apart by their dictionaries, since pdf.Value doesn't expose object references.
+func readBookmarks(r *pdf.Reader) []Bookmark {
+	numbers := make(map[string]int, r.NumPage())
+	for i := 1; i <= r.NumPage(); i++ {
+		numbers[r.Page(i).V.String()] = i
+	}
+	bookmarks := make([]Bookmark, 0)
+	for entry := r.Trailer().Key("Root").Key("Outlines").Key("First"); entry.Kind() == pdf.Dict; entry = entry.Key("Next") {
+		dest := entry.Key("Dest")
+		if dest.Kind() != pdf.Array {
+			dest = entry.Key("A").Key("D")
+		}
+		if dest.Kind() != pdf.Array {
+			continue
+		}
+		if number, ok := numbers[dest.Index(0).String()]; ok {
+			bookmarks = append(bookmarks, Bookmark{entry.Key("Title").Text(), number})
+		}
+	}
+	return bookmarks
+}
@@ -0,0 +1,38 @@
+// Package reader provides functions for reading pdf files.
+
+package reader
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/pdftest"
+)
+
+func TestReadContent_bookmarks(t *testing.T) {
+	page := pdftest.Page{Texts: []pdf.Text{{X: 46, Y: 500, S: "Title"}}}
+	content := pdftest.BuildOutline([]pdftest.Bookmark{{Title: "Группа ИВТ-101", Page: 1}, {Title: "Группа ИВТ-102", Page: 3}}, page, page, page)
+
+	got, err := ReadContent(bytes.NewReader(content), int64(len(content)), Options{Pages: []int{1, 2, 3}, Bookmarks: true})
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+	wantBookmarks := []Bookmark{{"Группа ИВТ-101", 1}, {"Группа ИВТ-102", 3}}
+	if !reflect.DeepEqual(got.Bookmarks, wantBookmarks) {
+		t.Errorf("ReadContent().Bookmarks = %v, want %v", got.Bookmarks, wantBookmarks)
+	}
+	wantStarts := []PageStart{{1, 0}, {2, 5}, {3, 10}}
+	if !reflect.DeepEqual(got.PageStarts, wantStarts) {
+		t.Errorf("ReadContent().PageStarts = %v, want %v", got.PageStarts, wantStarts)
+	}
+
+	got, err = ReadContent(bytes.NewReader(content), int64(len(content)), Options{})
+	if err != nil {
+		t.Fatalf("ReadContent() error = %v", err)
+	}
+	if got.Bookmarks != nil {
+		t.Errorf("ReadContent().Bookmarks = %v, want nil", got.Bookmarks)
+	}
+}
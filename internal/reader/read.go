@@ -12,16 +12,115 @@ import (
 	"github.com/ledongthuc/pdf"
 )
 
-// read returns pdf content from reader.
-func read(reader io.ReaderAt, size int64) ([]pdf.Text, error) {
+// Options determines which content is read besides texts.
+type Options struct {
+	// Fills enables reading of filled rectangles.
+	Fills bool
+	// Links enables reading of link annotations.
+	Links bool
+	// Pages are 1-based numbers of pages to read. Only the first page is read if it is empty.
+	Pages []int
+	// Bookmarks enables reading of top-level entries of document outline.
+	Bookmarks bool
+}
+
+// Content contains texts and, if requested, filled rectangles and links of pdf page.
+type Content struct {
+	Texts []pdf.Text
+	Fills []Fill
+	Links []Link
+	// PageStarts are number of each read page and index of its first text in Texts.
+	PageStarts []PageStart
+	// Bookmarks are top-level entries of document outline, if requested.
+	Bookmarks []Bookmark
+}
+
+// PageStart is 1-based number of pdf page and index of its first text in Content.Texts.
+type PageStart struct {
+	Number int
+	Index  int
+}
+
+// ReadContent returns content of pdf pages from reader.
+// Content of several pages is concatenated in order of opts.Pages.
+func ReadContent(reader io.ReaderAt, size int64, opts Options) (*Content, error) {
+	if len(opts.Pages) == 0 {
+		opts.Pages = []int{1}
+	}
+	content := &Content{}
+	err := ReadPages(reader, size, opts, func(page *Content) error {
+		for _, start := range page.PageStarts {
+			content.PageStarts = append(content.PageStarts, PageStart{start.Number, start.Index + len(content.Texts)})
+		}
+		content.Bookmarks = append(content.Bookmarks, page.Bookmarks...)
+		content.Texts = append(content.Texts, page.Texts...)
+		content.Fills = append(content.Fills, page.Fills...)
+		content.Links = append(content.Links, page.Links...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// ReadPages reads content of pdf pages from reader one page at a time and passes it to fn,
+// so that content of only one page is held in memory.
+// Pages are read in order of opts.Pages, or all pages are read if it is empty.
+// Bookmarks of document are passed in content of the first read page.
+// Reading stops at the first error returned by fn.
+func ReadPages(reader io.ReaderAt, size int64, opts Options, fn func(page *Content) error) error {
 	pdfReader, err := pdf.NewReader(reader, size)
 	if err != nil {
-		return nil, fmt.Errorf("reading error: %w", err)
+		return fmt.Errorf("reading error: %w", err)
+	}
+
+	pages := opts.Pages
+	if len(pages) == 0 {
+		pages = make([]int, pdfReader.NumPage())
+		for i := range pages {
+			pages[i] = i + 1
+		}
+	}
+	var bookmarks []Bookmark
+	if opts.Bookmarks {
+		bookmarks = readBookmarks(pdfReader)
 	}
+	for i, number := range pages {
+		if number < 1 || number > pdfReader.NumPage() {
+			return fmt.Errorf("page %d is out of range [1, %d]", number, pdfReader.NumPage())
+		}
+		page := pdfReader.Page(number)
+		content := &Content{Texts: page.Content().Text, PageStarts: []PageStart{{number, 0}}}
+		if i == 0 {
+			content.Bookmarks = bookmarks
+		}
+		if opts.Fills {
+			content.Fills = readFills(page)
+			for j := range content.Fills {
+				content.Fills[j].Page = number
+			}
+		}
+		if opts.Links {
+			content.Links = readLinks(page)
+			for j := range content.Links {
+				content.Links[j].Page = number
+			}
+		}
+		if err := fn(content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	page := pdfReader.Page(1)
-	texts := page.Content().Text
-	return texts, nil
+// Read returns pdf content from reader.
+func Read(reader io.ReaderAt, size int64) ([]pdf.Text, error) {
+	content, err := ReadContent(reader, size, Options{})
+	if err != nil {
+		return nil, err
+	}
+	return content.Texts, nil
 }
 
 // ReadFile reads file and returns slice of pdf.Text.
@@ -36,7 +135,7 @@ func ReadFile(filePath string) ([]pdf.Text, error) {
 		return nil, err
 	}
 
-	return read(file, fileInfo.Size())
+	return Read(file, fileInfo.Size())
 }
 
 // ReadBytes reads file bytes and returns slice of pdf.Text.
@@ -45,5 +144,5 @@ func ReadBytes(fileBytes []byte) ([]pdf.Text, error) {
 		return nil, errors.New("fileBytes is nil")
 	}
 	reader := bytes.NewReader(fileBytes)
-	return read(reader, reader.Size())
+	return Read(reader, reader.Size())
 }
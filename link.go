@@ -0,0 +1,120 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+// defaultCellWidth is distance between X coordinates of adjacent time slots of default layout,
+// used if header has no time slot labels.
+const defaultCellWidth = 93
+
+// getCellWidth takes slice of pdf.Text and returns distance between X coordinates of adjacent time slots of events table.
+// It is the smallest distance between time slot labels of header, e.g. "8:30-10:10", placed above cells.
+// Consecutive texts with the same Y coordinate and without gap between them form a label.
+func getCellWidth(texts []pdf.Text) float64 {
+	const maxGap = 30
+	xs := make([]float64, 0)
+	var (
+		label        string
+		labelX, x, y float64
+	)
+	flush := func() {
+		if timeLabelRegexp.MatchString(label) {
+			xs = append(xs, labelX)
+		}
+		label = ""
+	}
+	for _, text := range texts {
+		if text.Y < tableTop || text.X <= tableLeft {
+			continue
+		}
+		// Labels on the same line are separated by gap.
+		if label != "" && (text.Y != y || text.X < x || text.X-x > maxGap) {
+			flush()
+		}
+		if label == "" {
+			labelX = text.X
+		}
+		label += text.S
+		x, y = text.X, text.Y
+	}
+	flush()
+
+	sort.Float64s(xs)
+	width := 0.0
+	for i := 1; i < len(xs); i++ {
+		if distance := xs[i] - xs[i-1]; distance > 0 && (width == 0 || distance < width) {
+			width = distance
+		}
+	}
+	if width == 0 {
+		return defaultCellWidth
+	}
+	return width
+}
+
+// setMeetingURLs sets meeting URL of raw events to URIs of links placed within their cells.
+// Cell of raw event is taken to span cellWidth to the right of its position
+// and to last down to the next raw event, so link belongs to the lowest raw event
+// of its column starting not below the link. Link is matched only against raw events of its page.
+func setMeetingURLs(rawEvents []RawEvent, links []reader.Link, cellWidth float64) {
+	const tolerance = 2
+	for _, link := range links {
+		x, y := (link.Rect.Min.X+link.Rect.Max.X)/2, (link.Rect.Min.Y+link.Rect.Max.Y)/2
+		var cell *RawEvent
+		for i := range rawEvents {
+			if !samePage(rawEvents[i].page, link.Page) {
+				continue
+			}
+			pos := rawEvents[i].position
+			if x < pos.X-tolerance || x >= pos.X+cellWidth || pos.Y+tolerance < y {
+				continue
+			}
+			if cell == nil || pos.Y < cell.position.Y {
+				cell = &rawEvents[i]
+			}
+		}
+		if cell != nil && cell.meetingURL == "" {
+			cell.meetingURL = link.URI
+		}
+	}
+}
+
+// textLinkRegexp matches meeting link written as text of cell, e.g. "ссылка: https://example.com/j/1",
+// optionally parenthesized. URL is the second submatch and may end with punctuation separating segments.
+var textLinkRegexp = regexp.MustCompile(`(?i)(\(\s*)?ссылка\s*:?\s*(https?://[^\s()]+)(\s*\))?`)
+
+// extractTextLink returns data without the first meeting link written as text of cell and URL of link.
+// Link is extracted only if its URL is absolute http(s) URL, otherwise it is left as text and false is returned.
+// Separator following link, e.g. "." before dates, is removed with it.
+func extractTextLink(data string) (string, string, bool) {
+	for _, indexes := range textLinkRegexp.FindAllStringSubmatchIndex(data, -1) {
+		rawURL := strings.TrimRight(data[indexes[4]:indexes[5]], ".,;")
+		u, err := url.Parse(rawURL)
+		if err != nil || u.Host == "" {
+			continue
+		}
+		start, end := indexes[0], indexes[1]
+		if indexes[2] < 0 || indexes[6] < 0 {
+			// Unbalanced parenthesis isn't part of link.
+			if indexes[2] >= 0 {
+				start = indexes[3]
+			}
+			end = indexes[5]
+		}
+		before, after := strings.TrimSpace(data[:start]), strings.TrimSpace(strings.TrimLeft(data[end:], ".,;"))
+		if strings.HasPrefix(after, ")") {
+			return strings.TrimRight(before, ",;") + after, rawURL, true
+		}
+		return strings.TrimSpace(before + " " + after), rawURL, true
+	}
+	return data, "", false
+}
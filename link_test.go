@@ -0,0 +1,175 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/pdftest"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+func Test_setMeetingURLs(t *testing.T) {
+	rawEvents := []RawEvent{
+		{position: pdf.Point{X: 46, Y: 500}},
+		{position: pdf.Point{X: 46, Y: 430}},
+		{position: pdf.Point{X: 139, Y: 500}},
+	}
+	links := []reader.Link{
+		{Rect: pdf.Rect{Min: pdf.Point{X: 46, Y: 470}, Max: pdf.Point{X: 120, Y: 478}}, URI: "https://meet.example.com/first"},
+		{Rect: pdf.Rect{Min: pdf.Point{X: 46, Y: 400}, Max: pdf.Point{X: 120, Y: 408}}, URI: "https://meet.example.com/second"},
+		{Rect: pdf.Rect{Min: pdf.Point{X: 300, Y: 600}, Max: pdf.Point{X: 320, Y: 608}}, URI: "https://example.com"},
+	}
+
+	setMeetingURLs(rawEvents, links, defaultCellWidth)
+	got := []string{rawEvents[0].meetingURL, rawEvents[1].meetingURL, rawEvents[2].meetingURL}
+	if want := []string{"https://meet.example.com/first", "https://meet.example.com/second", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("meeting URLs = %q, want %q", got, want)
+	}
+}
+
+func Test_getCellWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []pdf.Text
+		want  float64
+	}{
+		{"TimeSlotLabels", []pdf.Text{
+			{X: 40, Y: 535, S: "Курс: 2"},
+			{X: 46, Y: 525, S: "8:30-10:00"},
+			{X: 106, Y: 525, S: "10:10-11:40"},
+			{X: 166, Y: 525, S: "11:50-13:20"},
+			{X: 46, Y: 500, S: "Title. лекции. [05.09]"},
+		}, 60},
+		{"WithoutLabels", []pdf.Text{
+			{X: 40, Y: 535, S: "Курс: 2"},
+			{X: 46, Y: 500, S: "Title. лекции. [05.09]"},
+		}, defaultCellWidth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getCellWidth(tt.texts); got != tt.want {
+				t.Errorf("getCellWidth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithMeetingURLs(t *testing.T) {
+	content := pdftest.BuildPages(pdftest.Page{
+		Texts: []pdf.Text{
+			{X: 46, Y: 500, S: "Online. Teacher T.T. лекции. Location."},
+			{X: 46, Y: 490, S: "[05.09-05.12 к.н.]"},
+			{X: 139, Y: 500, S: "Offline. Teacher T.T. лекции. Location. [05.09]"},
+		},
+		Links: []pdftest.Link{
+			{Rect: pdf.Rect{Min: pdf.Point{X: 46, Y: 488}, Max: pdf.Point{X: 120, Y: 498}}, URI: "https://meet.example.com/abc"},
+		},
+	})
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{"Enabled", []Option{WithMeetingURLs()}, []string{"https://meet.example.com/abc", ""}},
+		{"Disabled", nil, []string{"", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewParser(tt.opts...).ParseReader(bytes.NewReader(content), int64(len(content)), time.Time{})
+			if err != nil {
+				t.Fatalf("Parser.ParseReader() error = %v", err)
+			}
+			got := make([]string, len(schedule.Events))
+			for i, event := range schedule.Events {
+				got[i] = event.MeetingURL
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("meeting URLs = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithMeetingURLs_pages(t *testing.T) {
+	content := pdftest.BuildPages(
+		pdftest.Page{
+			Texts: []pdf.Text{{X: 46, Y: 500, S: "First. Teacher T.T. лекции. Location. [05.09]"}},
+		},
+		pdftest.Page{
+			Texts: []pdf.Text{{X: 46, Y: 500, S: "Second. Teacher T.T. лекции. Location. [05.09]"}},
+			Links: []pdftest.Link{
+				{Rect: pdf.Rect{Min: pdf.Point{X: 46, Y: 488}, Max: pdf.Point{X: 120, Y: 498}}, URI: "https://meet.example.com/abc"},
+			},
+		},
+	)
+
+	schedule, err := NewParser(WithMeetingURLs(), WithPages(1, 2)).ParseReader(bytes.NewReader(content), int64(len(content)), time.Time{})
+	if err != nil {
+		t.Fatalf("Parser.ParseReader() error = %v", err)
+	}
+	got := make([]string, len(schedule.Events))
+	for i, event := range schedule.Events {
+		got[i] = event.MeetingURL
+	}
+	if want := []string{"", "https://meet.example.com/abc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("meeting URLs = %q, want %q", got, want)
+	}
+}
+
+func TestParser_parseEvents_textLink(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		raw          RawEvent
+		wantURL      string
+		wantLocation string
+		wantNote     string
+	}{
+		{
+			"Location",
+			RawEvent{data: "Title. Teacher T.T. лекции. Location. Ссылка: https://meet.example.com/j/1?pwd=a.b. [05.09]"},
+			"https://meet.example.com/j/1?pwd=a.b", "Location", "",
+		},
+		{
+			"Note",
+			RawEvent{data: "Title. Teacher T.T. лекции. Location. [05.09] (ссылка: https://meet.example.com/j/2)"},
+			"https://meet.example.com/j/2", "Location", "",
+		},
+		{
+			"NoteWithText",
+			RawEvent{data: "Title. Teacher T.T. лекции. Location. [05.09] (перенос; ссылка https://meet.example.com/j/3)"},
+			"https://meet.example.com/j/3", "Location", "перенос",
+		},
+		{
+			"Annotation",
+			RawEvent{data: "Title. Teacher T.T. лекции. Location. ссылка: https://meet.example.com/j/4 [05.09]", meetingURL: "https://meet.example.com/annotation"},
+			"https://meet.example.com/annotation", "Location", "",
+		},
+		{
+			"InvalidURL",
+			RawEvent{data: "Title. Teacher T.T. лекции. Location. [05.09] (ссылка: https:///j/5)"},
+			"", "Location", "ссылка: https:///j/5",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.raw.initialDate = initialDate
+			events, err := NewParser().parseEvents([]RawEvent{tt.raw})
+			if err != nil {
+				t.Fatalf("Parser.parseEvents() error = %v", err)
+			}
+			event := events[0]
+			if event.MeetingURL != tt.wantURL || event.Location != tt.wantLocation || event.Note != tt.wantNote {
+				t.Errorf("Parser.parseEvents() = %q, %q, %q, want %q, %q, %q",
+					event.MeetingURL, event.Location, event.Note, tt.wantURL, tt.wantLocation, tt.wantNote)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"strings"
+	"time"
+)
+
+// splitTeachers returns comma-separated teachers of event.
+func splitTeachers(teacher string) []string {
+	teachers := make([]string, 0, 1)
+	for _, name := range strings.Split(teacher, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			teachers = append(teachers, name)
+		}
+	}
+	return teachers
+}
+
+// loadOptions contains settings of TeacherLoad.
+type loadOptions struct {
+	split bool
+}
+
+// LoadOption configures TeacherLoad.
+type LoadOption func(*loadOptions)
+
+// WithSplitLoad makes TeacherLoad divide duration of event with several teachers equally among them
+// instead of attributing full duration to each of them.
+func WithSplitLoad() LoadOption {
+	return func(o *loadOptions) {
+		o.split = true
+	}
+}
+
+// TeacherLoad returns total duration of occurrences of events per teacher across the term.
+// Recurrences of event dates are expanded, and events without teacher are ignored.
+func TeacherLoad(events []Event, opts ...LoadOption) map[string]time.Duration {
+	var o loadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	load := make(map[string]time.Duration)
+	for i := range events {
+		teachers := splitTeachers(events[i].Teacher)
+		if len(teachers) == 0 || events[i].Cancelled {
+			continue
+		}
+		var duration time.Duration
+		for _, occurrence := range Occurrences(events[i]) {
+			duration += occurrence.End.Sub(occurrence.Start)
+		}
+		if o.split {
+			duration /= time.Duration(len(teachers))
+		}
+		for _, teacher := range teachers {
+			load[teacher] += duration
+		}
+	}
+	return load
+}
@@ -0,0 +1,42 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTeacherLoad(t *testing.T) {
+	events := []Event{
+		{Title: "Weekly", Teacher: "Иванов И.И.", Type: "lecture", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 19, 10, 0, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+		{Title: "Overlapping", Teacher: "Иванов И.И., Петров П.П.", Type: "seminar", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 9, 0, 0, 0, loc), End: time.Date(2000, 9, 5, 11, 0, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "Biweekly", Teacher: "Петров П.П.", Type: "lab", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 6, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 20, 10, 0, 0, 0, loc), Frequency: FrequencyThroughout},
+		}},
+		{Title: "WithoutTeacher", Type: "lecture", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 6, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 6, 10, 0, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+
+	tests := []struct {
+		name string
+		opts []LoadOption
+		want map[string]time.Duration
+	}{
+		{"Full", nil, map[string]time.Duration{"Иванов И.И.": 6*time.Hour + 30*time.Minute, "Петров П.П.": 5 * time.Hour}},
+		{"Split", []LoadOption{WithSplitLoad()}, map[string]time.Duration{"Иванов И.И.": 5*time.Hour + 30*time.Minute, "Петров П.П.": 4 * time.Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TeacherLoad(events, tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TeacherLoad() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
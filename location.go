@@ -0,0 +1,88 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Location is building and room of event location.
+type Location struct {
+	Building string
+	Room     string
+}
+
+var (
+	buildingRegexp = regexp.MustCompile(`(?i)^(?:корп(?:ус)?\.?)\s*(.+)$`)
+	roomRegexp     = regexp.MustCompile(`(?i)^(?:ауд(?:итория)?\.?)\s*(.+)$`)
+	digitRegexp    = regexp.MustCompile(`\d`)
+)
+
+// ParseLocation parses building and room of location separated by "/" or "," in either order,
+// e.g. "ауд.101/корп.А", "корп. А, ауд. 101", "101/А" and "А/101".
+// Part labeled with "корп." is building and part labeled with "ауд." is room,
+// otherwise part with digits is room. It returns false if location can't be parsed this way.
+func ParseLocation(location string) (Location, bool) {
+	parts := strings.FieldsFunc(location, func(r rune) bool { return r == '/' || r == ',' })
+	if len(parts) == 0 || len(parts) > 2 {
+		return Location{}, false
+	}
+	var parsed Location
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		field := &parsed.Building
+		if submatches := buildingRegexp.FindStringSubmatch(part); submatches != nil {
+			part = submatches[1]
+		} else if submatches := roomRegexp.FindStringSubmatch(part); submatches != nil {
+			part, field = submatches[1], &parsed.Room
+		} else if digitRegexp.MatchString(part) {
+			field = &parsed.Room
+		}
+		if part = strings.TrimSpace(part); part == "" || *field != "" {
+			return Location{}, false
+		}
+		*field = part
+	}
+	if parsed.Room == "" {
+		return Location{}, false
+	}
+	return parsed, true
+}
+
+// CanonicalLocation returns location in canonical form "корп. А, ауд. 101", or "ауд. 101" without building.
+// Location that can't be parsed by ParseLocation is returned with collapsed whitespace.
+func CanonicalLocation(location string) string {
+	parsed, ok := ParseLocation(location)
+	if !ok {
+		return strings.Join(strings.Fields(location), " ")
+	}
+	if parsed.Building == "" {
+		return "ауд. " + parsed.Room
+	}
+	return "корп. " + parsed.Building + ", ауд. " + parsed.Room
+}
+
+// synonymKey returns key of location in dictionary of synonyms: lowercase with collapsed whitespace.
+func synonymKey(location string) string {
+	return strings.ToLower(strings.Join(strings.Fields(location), " "))
+}
+
+// newLocationSynonyms returns map of synonym keys to canonical labels of given synonyms.
+func newLocationSynonyms(synonyms map[string]string) map[string]string {
+	dictionary := make(map[string]string, len(synonyms))
+	for synonym, label := range synonyms {
+		dictionary[synonymKey(synonym)] = label
+	}
+	return dictionary
+}
+
+// onlineRegexp matches locations of remote classes.
+var onlineRegexp = regexp.MustCompile(`(?i)^(?:онлайн|online|дистанционно|дистант|дот)$`)
+
+// IsOnline reports whether event is remote class: it has meeting URL
+// or its location is "онлайн", "online", "дистанционно", "дистант" or "ДОТ".
+// Other labels of remote location, e.g. name of LMS, can be mapped to them by WithLocationSynonyms.
+func (event Event) IsOnline() bool {
+	return event.MeetingURL != "" || onlineRegexp.MatchString(strings.TrimSpace(event.Location))
+}
@@ -0,0 +1,85 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		want     Location
+		wantOk   bool
+	}{
+		{"RoomBuilding", "101/А", Location{"А", "101"}, true},
+		{"BuildingRoom", "А/101", Location{"А", "101"}, true},
+		{"Labeled", "ауд.101/корп.А", Location{"А", "101"}, true},
+		{"LabeledReversed", "корп. А, ауд. 101", Location{"А", "101"}, true},
+		{"LabeledDigitBuilding", "ауд. 12/корп. 2", Location{"2", "12"}, true},
+		{"RoomOnly", "ауд. 101", Location{Room: "101"}, true},
+		{"TwoRooms", "101/102", Location{}, false},
+		{"WithoutRoom", "Спортзал", Location{}, false},
+		{"Empty", "", Location{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseLocation(tt.location)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("ParseLocation() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestCanonicalLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		want     string
+	}{
+		{"RoomBuilding", "101/А", "корп. А, ауд. 101"},
+		{"BuildingRoom", "А/101", "корп. А, ауд. 101"},
+		{"Labeled", "ауд.101/корп.А", "корп. А, ауд. 101"},
+		{"Room", "101", "ауд. 101"},
+		{"Unparsed", "Спорт  зал", "Спорт зал"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalLocation(tt.location); got != tt.want {
+				t.Errorf("CanonicalLocation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithLocationSynonyms(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+	rawEvents := make([]RawEvent, 0)
+	for _, location := range []string{"ДК", "Дистанционно", "Moodle  LMS", "101"} {
+		rawEvents = append(rawEvents, RawEvent{data: "Title. Teacher T.T. лекции. " + location + ". [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate})
+	}
+	synonyms := map[string]string{"дк": "онлайн", "дистанционно": "онлайн", "Moodle LMS": "онлайн"}
+
+	events, err := NewParser(WithLocationSynonyms(synonyms)).parseEvents(rawEvents)
+	if err != nil {
+		t.Fatalf("Parser.parseEvents() error = %v", err)
+	}
+	want := []struct {
+		location, rawLocation string
+		online                bool
+	}{{"онлайн", "ДК", true}, {"онлайн", "Дистанционно", true}, {"онлайн", "Moodle  LMS", true}, {"101", "", false}}
+	for i, w := range want {
+		if events[i].Location != w.location || events[i].RawLocation != w.rawLocation || events[i].IsOnline() != w.online {
+			t.Errorf("events[%d] location, raw location, online = %q, %q, %v, want %q, %q, %v",
+				i, events[i].Location, events[i].RawLocation, events[i].IsOnline(), w.location, w.rawLocation, w.online)
+		}
+	}
+	if got := BuildIndex(events).ByRoom("онлайн"); len(got) != 3 {
+		t.Errorf("len(ByRoom()) = %d, want %d", len(got), 3)
+	}
+}
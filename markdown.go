@@ -0,0 +1,50 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// markdownReplacer escapes characters breaking markdown table cells.
+var markdownReplacer = strings.NewReplacer("|", `\|`, "\n", " ")
+
+// markdownBold returns s in bold, or empty string if s is empty.
+func markdownBold(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "**" + s + "**"
+}
+
+// WriteMarkdown writes occurrences of events to w as markdown tables, one table per day.
+// Titles and locations are bold. Pipe characters in fields are escaped. Cancelled events are skipped.
+func WriteMarkdown(events []Event, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	day := ""
+	for _, o := range eventOccurrences(events) {
+		if o.event.Cancelled {
+			continue
+		}
+		if d := o.Date.Format("2006-01-02"); d != day {
+			if day != "" {
+				fmt.Fprintln(bw)
+			}
+			day = d
+			fmt.Fprintf(bw, "### %s, %s\n\n", day, o.Start.Weekday())
+			fmt.Fprintln(bw, "| Time | Title | Type | Teacher | Location |")
+			fmt.Fprintln(bw, "| --- | --- | --- | --- | --- |")
+		}
+		fmt.Fprintf(bw, "| %s-%s | %s | %s | %s | %s |\n",
+			o.Start.Format("15:04"), o.End.Format("15:04"),
+			markdownBold(markdownReplacer.Replace(o.event.Title)),
+			markdownReplacer.Replace(o.event.Type),
+			markdownReplacer.Replace(o.event.Teacher),
+			markdownBold(markdownReplacer.Replace(o.event.Location)),
+		)
+	}
+	return bw.Flush()
+}
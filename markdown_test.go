@@ -0,0 +1,41 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	events := []Event{
+		{Title: "Second", Teacher: "Teacher T.T.", Type: "seminar", Location: "102", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 10, 20, 0, 0, loc), End: time.Date(2000, 9, 5, 11, 50, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "First | Part", Teacher: "Teacher T.T.", Type: "lecture", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 12, 10, 0, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+		{Title: "Cancelled", Cancelled: true, Dates: []EventDate{
+			{Start: time.Date(2000, 9, 6, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 6, 10, 0, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+	want := "### 2000-09-05, Tuesday\n\n" +
+		"| Time | Title | Type | Teacher | Location |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| 08:30-10:00 | **First \\| Part** | lecture | Teacher T.T. |  |\n" +
+		"| 10:20-11:50 | **Second** | seminar | Teacher T.T. | **102** |\n" +
+		"\n" +
+		"### 2000-09-12, Tuesday\n\n" +
+		"| Time | Title | Type | Teacher | Location |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| 08:30-10:00 | **First \\| Part** | lecture | Teacher T.T. |  |\n"
+
+	var buf bytes.Buffer
+	if err := WriteMarkdown(events, &buf); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("WriteMarkdown() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,30 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// MigrateJSON reads events encoded in legacy flat shape produced by WithLegacyJSON from r
+// and writes them to w in shape of EventDate.MarshalJSON with date and start/end times.
+func MigrateJSON(r io.Reader, w io.Writer) error {
+	var legacyEvents []legacyEvent
+	if err := json.NewDecoder(r).Decode(&legacyEvents); err != nil {
+		return fmt.Errorf("decoding error: %w", err)
+	}
+	events := make([]Event, len(legacyEvents))
+	for i, legacy := range legacyEvents {
+		events[i] = legacy.Event
+		events[i].Dates = make([]EventDate, len(legacy.Dates))
+		for j, date := range legacy.Dates {
+			events[i].Dates[j] = EventDate(date)
+		}
+	}
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		return fmt.Errorf("encoding error: %w", err)
+	}
+	return nil
+}
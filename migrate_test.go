@@ -0,0 +1,54 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigrateJSON(t *testing.T) {
+	events := []Event{{
+		Title:    "Title",
+		Teacher:  "Teacher T.T.",
+		Type:     "lecture",
+		Location: "101",
+		Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+			{Start: time.Date(2000, 12, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 12, 8, 30, 0, 0, loc), Frequency: FrequencyOnce, OpenEnded: true},
+		},
+		Note: "Note",
+	}}
+	legacy, err := NewParser(WithLegacyJSON()).marshal(events)
+	if err != nil {
+		t.Fatalf("Parser.marshal() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := MigrateJSON(bytes.NewReader(legacy), &buf); err != nil {
+		t.Fatalf("MigrateJSON() error = %v", err)
+	}
+	want, err := NewParser().marshal(events)
+	if err != nil {
+		t.Fatalf("Parser.marshal() error = %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != string(want) {
+		t.Errorf("MigrateJSON() = %s, want %s", got, want)
+	}
+
+	var got []Event
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, events) {
+		t.Errorf("json.Unmarshal() = %v, want %v", got, events)
+	}
+
+	if err := MigrateJSON(strings.NewReader(`{"title":"Title"}`), &buf); err == nil {
+		t.Errorf("MigrateJSON() error = %v, wantErr %v", err, true)
+	}
+}
@@ -0,0 +1,24 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import "reflect"
+
+// fieldNormalizer is function normalizing string field with name of Event struct, see WithFieldNormalizer.
+type fieldNormalizer struct {
+	field string
+	fn    func(string) string
+}
+
+// normalizeFields replaces string fields of event by results of normalizers in their order.
+func normalizeFields(event *Event, normalizers []fieldNormalizer) {
+	if len(normalizers) == 0 {
+		return
+	}
+	value := reflect.ValueOf(event).Elem()
+	for _, normalizer := range normalizers {
+		if field := value.FieldByName(normalizer.field); field.IsValid() && field.Kind() == reflect.String && field.CanSet() {
+			field.SetString(normalizer.fn(field.String()))
+		}
+	}
+}
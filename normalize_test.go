@@ -0,0 +1,48 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func TestWithFieldNormalizer(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+	rawEvents := []RawEvent{
+		{data: "Матемтика. Teacher T.T. лекции. ауд 101. [05.09]", position: pdf.Point{X: 46, Y: 0}, initialDate: initialDate},
+	}
+	fixTitle := func(title string) string {
+		return strings.ReplaceAll(title, "Матемтика", "Математика")
+	}
+	rooms := map[string]string{"ауд 101": "101"}
+	mapRoom := func(location string) string {
+		if room, ok := rooms[location]; ok {
+			return room
+		}
+		return location
+	}
+
+	events, err := NewParser(
+		WithFieldNormalizer("Title", fixTitle),
+		WithFieldNormalizer("Location", mapRoom),
+		WithFieldNormalizer("Title", strings.ToUpper),
+		WithFieldNormalizer("Dates", strings.ToUpper),
+		WithFieldNormalizer("Unknown", strings.ToUpper),
+	).parseEvents(rawEvents)
+	if err != nil {
+		t.Fatalf("Parser.parseEvents() error = %v", err)
+	}
+	if want := "МАТЕМАТИКА"; events[0].Title != want {
+		t.Errorf("events[0].Title = %q, want %q", events[0].Title, want)
+	}
+	if want := "101"; events[0].Location != want {
+		t.Errorf("events[0].Location = %q, want %q", events[0].Location, want)
+	}
+	if want := "Teacher T.T."; events[0].Teacher != want {
+		t.Errorf("events[0].Teacher = %q, want %q", events[0].Teacher, want)
+	}
+}
@@ -0,0 +1,260 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// period returns number of days between consecutive occurrences,
+// or 0 if frequency doesn't recur.
+func (frequency Frequency) period() int {
+	switch frequency {
+	case FrequencyEvery:
+		return 7
+	case FrequencyThroughout:
+		return 14
+	case FrequencyContinuous:
+		return 1
+	}
+	return 0
+}
+
+// count returns number of occurrences of event date within its range except excluded ones.
+func (eventDate *EventDate) count() int {
+	period := eventDate.Frequency.period()
+	if period == 0 {
+		return 1
+	}
+	count := 0
+	for date := eventDate.Start; !date.After(eventDate.End); date = date.AddDate(0, 0, period) {
+		if !eventDate.excludes(days(date)) {
+			count++
+		}
+	}
+	return count
+}
+
+// OccurrenceCount returns number of times event takes place
+// according to frequency and range of its dates.
+func OccurrenceCount(event Event) int {
+	count := 0
+	for i := range event.Dates {
+		count += event.Dates[i].count()
+	}
+	return count
+}
+
+// days returns number of days since start of Unix epoch to civil date of t.
+func days(t time.Time) int {
+	year, month, day := t.Date()
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+}
+
+// excludes reports whether occurrence on given day since start of Unix epoch is excluded from event date.
+func (eventDate *EventDate) excludes(day int) bool {
+	for _, exception := range eventDate.Exceptions {
+		if days(exception.In(eventDate.Start.Location())) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// occursOn returns interval of event date occurrence on civil date of given time
+// and false if event date doesn't occur on it.
+func (eventDate *EventDate) occursOn(date time.Time) (Interval, bool) {
+	date = date.In(eventDate.Start.Location())
+	day, first, last := days(date), days(eventDate.Start), days(eventDate.End)
+	if day < first || day > last {
+		return Interval{}, false
+	}
+	period := eventDate.Frequency.period()
+	if (period == 0 && day != first) || (period != 0 && (day-first)%period != 0) || eventDate.excludes(day) {
+		return Interval{}, false
+	}
+	offset := day - first
+	end := eventDate.End.AddDate(0, 0, offset-(last-first))
+	return Interval{eventDate.Start.AddDate(0, 0, offset), end}, true
+}
+
+// intervals returns intervals of all occurrences of event date in chronological order.
+func (eventDate *EventDate) intervals() []Interval {
+	period := eventDate.Frequency.period()
+	first, last := days(eventDate.Start), days(eventDate.End)
+	if period == 0 {
+		period = last - first + 1
+	}
+	intervals := make([]Interval, 0, eventDate.count())
+	for offset := 0; offset <= last-first; offset += period {
+		if eventDate.excludes(first + offset) {
+			continue
+		}
+		end := eventDate.End.AddDate(0, 0, offset-(last-first))
+		intervals = append(intervals, Interval{eventDate.Start.AddDate(0, 0, offset), end})
+	}
+	return intervals
+}
+
+// onWeekday returns part of event date occurring on weekday and false if event date doesn't occur on it.
+// Continuous date is converted to weekly date between its first and last occurrences on weekday.
+func (eventDate EventDate) onWeekday(weekday time.Weekday) (EventDate, bool) {
+	if eventDate.Frequency != FrequencyContinuous {
+		return eventDate.Clone(), eventDate.Start.Weekday() == weekday
+	}
+	start := eventDate.Start.AddDate(0, 0, (int(weekday)-int(eventDate.Start.Weekday())+7)%7)
+	end := eventDate.End.AddDate(0, 0, -(int(eventDate.End.Weekday())-int(weekday)+7)%7)
+	if days(start) > days(end) {
+		return EventDate{}, false
+	}
+	date := eventDate.Clone()
+	date.Start, date.End, date.Frequency, date.Exceptions = start, end, FrequencyEvery, nil
+	for _, exception := range eventDate.Exceptions {
+		if exception.Weekday() == weekday {
+			date.Exceptions = append(date.Exceptions, exception)
+		}
+	}
+	return date, true
+}
+
+// Occurrence is single occurrence of event with recurrence expanded and time applied.
+type Occurrence struct {
+	Date  time.Time // civil date of occurrence at midnight
+	Start time.Time
+	End   time.Time
+}
+
+// checkOccurrenceCount returns error wrapping ErrTooManyOccurrences if event occurs more than max times.
+// Zero max means no limit.
+func checkOccurrenceCount(event *Event, max int) error {
+	if max <= 0 {
+		return nil
+	}
+	if count := OccurrenceCount(*event); count > max {
+		return fmt.Errorf("%w: event %q occurs %d times, more than %d", ErrTooManyOccurrences, event.Title, count, max)
+	}
+	return nil
+}
+
+// ExpandOccurrences returns occurrences of event as Occurrences does, or error wrapping ErrTooManyOccurrences
+// without expanding them if event occurs more than max times, e.g. because of erroneous date range
+// of event built by hand or decoded from json. Zero max means no limit.
+func ExpandOccurrences(event Event, max int) ([]Occurrence, error) {
+	if err := checkOccurrenceCount(&event, max); err != nil {
+		return nil, err
+	}
+	return Occurrences(event), nil
+}
+
+// Occurrences returns all occurrences of event according to frequency and range of its dates
+// in chronological order. Number of occurrences isn't limited, see ExpandOccurrences.
+func Occurrences(event Event) []Occurrence {
+	occurrences := make([]Occurrence, 0, OccurrenceCount(event))
+	for i := range event.Dates {
+		for _, interval := range event.Dates[i].intervals() {
+			year, month, day := interval.Start.Date()
+			date := time.Date(year, month, day, 0, 0, 0, 0, interval.Start.Location())
+			occurrences = append(occurrences, Occurrence{date, interval.Start, interval.End})
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool { return occurrences[i].Start.Before(occurrences[j].Start) })
+	return occurrences
+}
+
+// occurrenceDates returns distinct civil dates of occurrences of event as "YYYY-MM-DD" in chronological order.
+func occurrenceDates(event Event) []string {
+	dates := make([]string, 0)
+	for _, o := range Occurrences(event) {
+		date := o.Date.Format(jsonDateLayout)
+		if len(dates) == 0 || dates[len(dates)-1] != date {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// eventOccurrence is occurrence of event.
+type eventOccurrence struct {
+	Occurrence
+	event *Event
+}
+
+// eventOccurrences returns occurrences of all events in chronological order.
+// Occurrences starting at the same time are kept in order of events.
+func eventOccurrences(events []Event) []eventOccurrence {
+	occurrences := make([]eventOccurrence, 0)
+	for i := range events {
+		for _, o := range Occurrences(events[i]) {
+			occurrences = append(occurrences, eventOccurrence{o, &events[i]})
+		}
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool { return occurrences[i].Start.Before(occurrences[j].Start) })
+	return occurrences
+}
+
+// EventsInWeek returns occurrences of events within ISO week, i.e. Monday to Sunday, containing civil date of given date.
+// Every occurrence is returned as clone of its event with single date of occurrence, in chronological order.
+func EventsInWeek(events []Event, anyDateInWeek time.Time) []Event {
+	monday := days(anyDateInWeek) - (int(anyDateInWeek.Weekday())+6)%7
+	weekEvents := make([]Event, 0)
+	for _, o := range eventOccurrences(events) {
+		if day := days(o.Start); day < monday || day >= monday+7 {
+			continue
+		}
+		event := o.event.Clone()
+		event.Dates = []EventDate{{Start: o.Start, End: o.End, Frequency: FrequencyOnce}}
+		weekEvents = append(weekEvents, event)
+	}
+	return weekEvents
+}
+
+// next returns interval of the first occurrence of event date starting at or after now
+// and false if event date has no such occurrence. Occurrences preceding now aren't expanded.
+func (eventDate *EventDate) next(now time.Time) (Interval, bool) {
+	period := eventDate.Frequency.period()
+	first, last := days(eventDate.Start), days(eventDate.End)
+	if period == 0 {
+		period = last - first + 1
+	}
+	offset := 0
+	if day := days(now.In(eventDate.Start.Location())); day > first {
+		offset = (day - first) / period * period
+	}
+	for ; offset <= last-first; offset += period {
+		start := eventDate.Start.AddDate(0, 0, offset)
+		if start.Before(now) || eventDate.excludes(first+offset) {
+			continue
+		}
+		return Interval{start, eventDate.End.AddDate(0, 0, offset-(last-first))}, true
+	}
+	return Interval{}, false
+}
+
+// NextEvent returns the soonest occurrence of events starting at or after now, i.e. occurrence starting
+// exactly at now is upcoming, with its event and true, or false if no occurrence starts at or after now.
+// Occurrences starting at the same time are resolved in order of events. Cancelled events are skipped.
+func NextEvent(events []Event, now time.Time) (*Event, *Occurrence, bool) {
+	var (
+		nextEvent *Event
+		next      Interval
+	)
+	for i := range events {
+		if events[i].Cancelled {
+			continue
+		}
+		for j := range events[i].Dates {
+			interval, ok := events[i].Dates[j].next(now)
+			if ok && (nextEvent == nil || interval.Start.Before(next.Start)) {
+				nextEvent, next = &events[i], interval
+			}
+		}
+	}
+	if nextEvent == nil {
+		return nil, nil, false
+	}
+	year, month, day := next.Start.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, next.Start.Location())
+	return nextEvent, &Occurrence{date, next.Start, next.End}, true
+}
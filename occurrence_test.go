@@ -0,0 +1,227 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOccurrenceCount(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+
+	tests := []struct {
+		name  string
+		event Event
+		want  int
+	}{
+		{
+			"Weekly",
+			Event{Dates: []EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 10, 3, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+			}},
+			5,
+		},
+		{
+			"Biweekly",
+			Event{Dates: []EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 10, 3, 10, 10, 0, 0, loc), Frequency: FrequencyThroughout},
+			}},
+			3,
+		},
+		{
+			"Continuous",
+			Event{Dates: []EventDate{
+				{Start: time.Date(2000, 9, 5, 0, 0, 0, 0, loc), End: time.Date(2000, 9, 18, 23, 59, 0, 0, loc), Frequency: FrequencyContinuous},
+			}},
+			14,
+		},
+		{
+			"Single",
+			Event{Dates: []EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+			}},
+			1,
+		},
+		{
+			"Hybrid",
+			Event{Dates: []EventDate{
+				{Start: time.Date(2000, 9, 2, 12, 20, 0, 0, loc), End: time.Date(2000, 10, 28, 14, 0, 0, 0, loc), Frequency: FrequencyEvery},
+				{Start: time.Date(2000, 11, 11, 12, 20, 0, 0, loc), End: time.Date(2000, 11, 11, 14, 0, 0, 0, loc), Frequency: FrequencyOnce},
+			}},
+			10,
+		},
+		{
+			"WithoutDates",
+			Event{},
+			0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OccurrenceCount(tt.event); got != tt.want {
+				t.Errorf("OccurrenceCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	occurrence := func(day, startHour, endHour int) Occurrence {
+		return Occurrence{
+			Date:  time.Date(2000, 9, day, 0, 0, 0, 0, loc),
+			Start: time.Date(2000, 9, day, startHour, 30, 0, 0, loc),
+			End:   time.Date(2000, 9, day, endHour, 10, 0, 0, loc),
+		}
+	}
+
+	tests := []struct {
+		name  string
+		event Event
+		want  []Occurrence
+	}{
+		{
+			"Weekly",
+			Event{Dates: []EventDate{
+				{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 19, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+			}},
+			[]Occurrence{occurrence(5, 8, 10), occurrence(12, 8, 10), occurrence(19, 8, 10)},
+		},
+		{
+			"Biweekly",
+			Event{Dates: []EventDate{
+				{Start: time.Date(2000, 9, 6, 12, 30, 0, 0, loc), End: time.Date(2000, 9, 20, 14, 10, 0, 0, loc), Frequency: FrequencyThroughout},
+			}},
+			[]Occurrence{occurrence(6, 12, 14), occurrence(20, 12, 14)},
+		},
+		{
+			"Chronological",
+			Event{Dates: []EventDate{
+				{Start: time.Date(2000, 9, 13, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 13, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+				{Start: time.Date(2000, 9, 6, 12, 30, 0, 0, loc), End: time.Date(2000, 9, 20, 14, 10, 0, 0, loc), Frequency: FrequencyThroughout},
+			}},
+			[]Occurrence{occurrence(6, 12, 14), occurrence(13, 8, 10), occurrence(20, 12, 14)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Occurrences(tt.event); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandOccurrences(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	// Erroneous year of end date makes range occur every week of 100 years.
+	event := Event{Title: "Title", Dates: []EventDate{
+		{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2100, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+	}}
+
+	if _, err := ExpandOccurrences(event, 1000); !errors.Is(err, ErrTooManyOccurrences) {
+		t.Errorf("ExpandOccurrences() error = %v, want %v", err, ErrTooManyOccurrences)
+	}
+	got, err := ExpandOccurrences(event, 0)
+	if err != nil {
+		t.Fatalf("ExpandOccurrences() error = %v", err)
+	}
+	if want := OccurrenceCount(event); len(got) != want {
+		t.Errorf("len(ExpandOccurrences()) = %d, want %d", len(got), want)
+	}
+}
+
+func TestEventsInWeek(t *testing.T) {
+	events := []Event{
+		{Title: "Weekly", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 4, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 25, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+		{Title: "Sunday", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 17, 12, 30, 0, 0, loc), End: time.Date(2000, 9, 17, 14, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "Saturday", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 16, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 16, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+	occurrence := func(title string, day, hour, min int) string {
+		return title + time.Date(2000, 9, day, hour, min, 0, 0, loc).Format(" 2006-01-02 15:04")
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want []string
+	}{
+		{"Monday", time.Date(2000, 9, 11, 0, 0, 0, 0, loc), []string{occurrence("Weekly", 11, 8, 30), occurrence("Saturday", 16, 8, 30), occurrence("Sunday", 17, 12, 30)}},
+		{"Sunday", time.Date(2000, 9, 17, 23, 59, 0, 0, loc), []string{occurrence("Weekly", 11, 8, 30), occurrence("Saturday", 16, 8, 30), occurrence("Sunday", 17, 12, 30)}},
+		{"NextMonday", time.Date(2000, 9, 18, 0, 0, 0, 0, loc), []string{occurrence("Weekly", 18, 8, 30)}},
+		{"BeforeEvents", time.Date(2000, 9, 3, 0, 0, 0, 0, loc), []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, event := range EventsInWeek(events, tt.date) {
+				if len(event.Dates) != 1 || event.Dates[0].Frequency != FrequencyOnce {
+					t.Fatalf("EventsInWeek() event dates = %v, want single date", event.Dates)
+				}
+				got = append(got, event.Title+event.Dates[0].Start.Format(" 2006-01-02 15:04"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EventsInWeek() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextEvent(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	clock := func() time.Time { return time.Date(2000, 9, 12, 8, 30, 0, 0, loc) }
+	events := []Event{
+		{Title: "Weekly", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 26, 10, 10, 0, 0, loc), Frequency: FrequencyEvery,
+				Exceptions: []time.Time{time.Date(2000, 9, 19, 0, 0, 0, 0, loc)}},
+		}},
+		{Title: "Once", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 14, 12, 30, 0, 0, loc), End: time.Date(2000, 9, 14, 14, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "Cancelled", Cancelled: true, Dates: []EventDate{
+			{Start: time.Date(2000, 9, 13, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 13, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantTitle string
+		wantStart time.Time
+		wantOK    bool
+	}{
+		{"BeforeAll", time.Date(2000, 9, 1, 0, 0, 0, 0, loc), "Weekly", time.Date(2000, 9, 5, 8, 30, 0, 0, loc), true},
+		{"AtStart", clock(), "Weekly", time.Date(2000, 9, 12, 8, 30, 0, 0, loc), true},
+		{"AfterStart", clock().Add(time.Nanosecond), "Once", time.Date(2000, 9, 14, 12, 30, 0, 0, loc), true},
+		{"CancelledSkipped", time.Date(2000, 9, 13, 0, 0, 0, 0, loc), "Once", time.Date(2000, 9, 14, 12, 30, 0, 0, loc), true},
+		{"ExceptionSkipped", time.Date(2000, 9, 15, 0, 0, 0, 0, loc), "Weekly", time.Date(2000, 9, 26, 8, 30, 0, 0, loc), true},
+		{"OtherZone", time.Date(2000, 9, 26, 5, 30, 0, 0, time.UTC), "Weekly", time.Date(2000, 9, 26, 8, 30, 0, 0, loc), true},
+		{"AfterAll", time.Date(2000, 9, 26, 8, 31, 0, 0, loc), "", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, occurrence, ok := NextEvent(events, tt.now)
+			if ok != tt.wantOK {
+				t.Fatalf("NextEvent() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if event.Title != tt.wantTitle || !occurrence.Start.Equal(tt.wantStart) {
+				t.Errorf("NextEvent() = %q at %v, want %q at %v", event.Title, occurrence.Start, tt.wantTitle, tt.wantStart)
+			}
+			if want := tt.wantStart.Add(100 * time.Minute); !occurrence.End.Equal(want) {
+				t.Errorf("NextEvent() occurrence end = %v, want %v", occurrence.End, want)
+			}
+		})
+	}
+}
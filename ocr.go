@@ -0,0 +1,86 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ocrDigits maps letters that OCR confuses with digits to these digits.
+var ocrDigits = map[rune]rune{
+	'О': '0', 'о': '0', 'O': '0', 'o': '0',
+	'З': '3', 'з': '3',
+	'б': '6',
+	'l': '1', 'I': '1',
+}
+
+// wordRegexp matches sequence of letters and digits.
+var wordRegexp = regexp.MustCompile(`[\pL\d]+`)
+
+// repairWord returns word with confusable letters replaced by digits
+// and false if word contains other letters.
+func repairWord(word string) (string, bool) {
+	repaired := []rune(word)
+	for i, r := range repaired {
+		if digit, ok := ocrDigits[r]; ok {
+			repaired[i] = digit
+		} else if !unicode.IsDigit(r) {
+			return word, false
+		}
+	}
+	return string(repaired), true
+}
+
+// followsNumber reports whether prefix ends with digit followed by "." or ":".
+func followsNumber(prefix string) bool {
+	n := len(prefix)
+	return n >= 2 && (prefix[n-1] == '.' || prefix[n-1] == ':') && prefix[n-2] >= '0' && prefix[n-2] <= '9'
+}
+
+// RepairOCR replaces letters confused with digits by OCR in numeric words of s,
+// e.g. "14.О9" becomes "14.09" and "1О5" becomes "105".
+// Word is repaired only if it consists of digits and confusable letters, and it contains a digit
+// or follows digit and "." or ":", so that words of text are kept unchanged.
+func RepairOCR(s string) string {
+	var b strings.Builder
+	last := 0
+	for _, indexes := range wordRegexp.FindAllStringIndex(s, -1) {
+		word := s[indexes[0]:indexes[1]]
+		repaired, ok := repairWord(word)
+		if !ok || repaired == word {
+			continue
+		}
+		if !strings.ContainsAny(word, "0123456789") && !followsNumber(s[:indexes[0]]) {
+			continue
+		}
+		b.WriteString(s[last:indexes[0]])
+		b.WriteString(repaired)
+		last = indexes[1]
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// repairCell repairs data of raw event with RepairOCR only within dates in brackets
+// and location following type, e.g. "1О5. [14.О9]" of "Title. лекции. 1О5. [14.О9] (Note)",
+// so that title, teacher and note are kept unchanged.
+func repairCell(data string) string {
+	datesIndexes := datesRegexp.FindAllStringIndex(data, -1)
+	if datesIndexes == nil {
+		return data
+	}
+	datesStart, datesEnd := datesIndexes[len(datesIndexes)-1][0], datesIndexes[len(datesIndexes)-1][1]
+	data = data[:datesStart] + RepairOCR(data[datesStart:datesEnd]) + data[datesEnd:]
+	typeIndexes := typeRegexp(eventTypes).FindStringIndex(data)
+	if typeIndexes == nil {
+		return data
+	}
+	// Location ends data of cell starting with dates, e.g. "[14.О9] Title. лекции. 1О5".
+	locationEnd := datesStart
+	if typeIndexes[0] >= datesEnd {
+		locationEnd = len(data)
+	}
+	return data[:typeIndexes[1]] + RepairOCR(data[typeIndexes[1]:locationEnd]) + data[locationEnd:]
+}
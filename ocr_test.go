@@ -0,0 +1,45 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import "testing"
+
+func TestRepairOCR(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{"Date", "[14.О9]", "[14.09]"},
+		{"DateRange", "[О5.О9-З1.10 к.н.]", "[05.09-31.10 к.н.]"},
+		{"Room", "ауд. 1О5", "ауд. 105"},
+		{"OpenEnded", "[12.12 с 14:ЗО до конца дня]", "[12.12 с 14:30 до конца дня]"},
+		{"Text", "Основы ООП. Иванов И.И. лекции.", "Основы ООП. Иванов И.И. лекции."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RepairOCR(tt.s); got != tt.want {
+				t.Errorf("RepairOCR() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_repairCell(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"DatesAndLocation", "Химия 1О. Иванов И.И. лекции. 1О5. [14.О9] (ауд. 2О1)", "Химия 1О. Иванов И.И. лекции. 105. [14.09] (ауд. 2О1)"},
+		{"LeadingDates", "[14.О9] Химия 1О. лекции. 1О5", "[14.09] Химия 1О. лекции. 105"},
+		{"WithoutDates", "Химия 1О. лекции. 1О5.", "Химия 1О. лекции. 1О5."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repairCell(tt.data); got != tt.want {
+				t.Errorf("repairCell() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
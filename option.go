@@ -0,0 +1,335 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import "time"
+
+// Option configures Parser.
+type Option func(*Parser)
+
+// WithLegacyJSON makes Parser encode event dates in legacy flat shape
+// with start/end datetimes instead of date with start/end times.
+func WithLegacyJSON() Option {
+	return func(p *Parser) {
+		p.legacyJSON = true
+	}
+}
+
+// WithOccurrencesJSON makes Parser encode events with "occurrences" field listing civil dates of their occurrences
+// as "YYYY-MM-DD" alongside dates with frequency, for clients that can't expand recurrences.
+func WithOccurrencesJSON() Option {
+	return func(p *Parser) {
+		p.occurrencesJSON = true
+	}
+}
+
+// WithFieldNames makes Parser rename fields of events in json encoding by names,
+// e.g. map[string]string{"title": "name"} encodes title as "name".
+// Only top-level fields of events are renamed, and fields missing in names keep default names.
+func WithFieldNames(names map[string]string) Option {
+	return func(p *Parser) {
+		p.fieldNames = make(map[string]string, len(names))
+		for field, name := range names {
+			p.fieldNames[field] = name
+		}
+	}
+}
+
+// WithSourceFile sets source file name of events parsed from reader or bytes.
+// File-based parsing uses input file path instead.
+func WithSourceFile(name string) Option {
+	return func(p *Parser) {
+		p.sourceFile = name
+	}
+}
+
+// WithErrorHandler makes Parser pass raw events that fail to parse to handler
+// with their index and exclude them from output instead of returning error.
+// Events with dates outside of validity period of schedule are passed to handler with ErrOutOfValidity
+// and kept in output.
+func WithErrorHandler(handler func(index int, raw RawEvent, err error)) Option {
+	return func(p *Parser) {
+		p.errorHandler = handler
+	}
+}
+
+// WithClock sets function returning current time, which is used instead of time.Now
+// when initial date is zero.
+func WithClock(clock func() time.Time) Option {
+	return func(p *Parser) {
+		p.clock = clock
+	}
+}
+
+// WithReferenceYear makes Parser infer years of dates by academic year starting in given year:
+// dates from August to December belong to year, and dates from January to July belong to the next year.
+// Reference year takes precedence over initial date, which takes precedence over validity period of schedule
+// and then over clock.
+func WithReferenceYear(year int) Option {
+	return func(p *Parser) {
+		p.referenceYear = year
+	}
+}
+
+// WithPages makes Parser read only given 1-based pages of pdf file instead of the first one.
+// Events of several pages are concatenated in order of pages.
+// Parsing fails if pdf file has no page with given number.
+func WithPages(pages ...int) Option {
+	return func(p *Parser) {
+		p.pages = append([]int(nil), pages...)
+	}
+}
+
+// WithHighlight makes Parser detect events whose cells have colored background.
+// Only rectangles filled directly in page content stream are recognized,
+// so highlighting drawn by other means (e.g. images or annotations) isn't detected.
+func WithHighlight() Option {
+	return func(p *Parser) {
+		p.highlight = true
+	}
+}
+
+// WithMaxEvents makes Parser return ErrTooManyEvents
+// when pdf content contains more than n events. Zero n means no limit.
+func WithMaxEvents(n int) Option {
+	return func(p *Parser) {
+		p.maxEvents = n
+	}
+}
+
+// WithMaxCellLength makes Parser return ErrCellTooLong
+// when text of event cell is longer than n bytes. Zero n means no limit.
+func WithMaxCellLength(n int) Option {
+	return func(p *Parser) {
+		p.maxCellLength = n
+	}
+}
+
+// WithMaxDateSpan makes Parser reject events with date range
+// longer than n days, returning DateSpanError. Zero n means no limit.
+func WithMaxDateSpan(n int) Option {
+	return func(p *Parser) {
+		p.maxDateSpan = n
+	}
+}
+
+// WithMaxOccurrences makes Parser reject events occurring more than n times according to frequency and range
+// of their dates, returning error wrapping ErrTooManyOccurrences, so that exporters expanding recurrences
+// don't produce excessive occurrences of erroneous range. Zero n means no limit.
+// Events built by hand or decoded are limited by ExpandOccurrences, WithICSMaxOccurrences and WithExpandedMaxOccurrences.
+func WithMaxOccurrences(n int) Option {
+	return func(p *Parser) {
+		p.maxOccurrences = n
+	}
+}
+
+// WithFootnotes makes Parser read texts below top Y coordinate as footnotes.
+// Footnote starts with marker such as "*" or "¹", and markers in event cells are replaced
+// by text of corresponding footnotes appended to Event.Note.
+func WithFootnotes(top float64) Option {
+	return func(p *Parser) {
+		p.footnotesTop = top
+	}
+}
+
+// WithRepairOCR makes Parser repair dates and location of raw events with RepairOCR before parsing.
+// It is intended for OCR-sourced pdf files and can misfire on real text.
+func WithRepairOCR() Option {
+	return func(p *Parser) {
+		p.repairOCR = true
+	}
+}
+
+// WithRawDates makes Parser keep dates of each event as they are written in pdf content,
+// which are available by Event.RawDates. Normalized Event.Dates are parsed as usual.
+func WithRawDates() Option {
+	return func(p *Parser) {
+		p.rawDates = true
+	}
+}
+
+// WithNormalizeTeacher makes Parser normalize teacher of each event with NormalizeTeacher.
+// Teacher as it is written in pdf content is available by Event.RawTeacher.
+func WithNormalizeTeacher() Option {
+	return func(p *Parser) {
+		p.normalizeTeacher = true
+	}
+}
+
+// WithSegments makes Parser keep lines of text that form each RawEvent,
+// which are available by RawEvent.Segments.
+func WithSegments() Option {
+	return func(p *Parser) {
+		p.segments = true
+	}
+}
+
+// WithMinConfidence makes Parser score Confidence of events and exclude events with confidence less than min,
+// passing them to error handler with ErrLowConfidence if Parser has one. Zero min means no scoring.
+func WithMinConfidence(min float64) Option {
+	return func(p *Parser) {
+		p.minConfidence = min
+	}
+}
+
+// WithTextRuns makes Parser keep texts of pdf content with their coordinates that form each RawEvent,
+// which are available by RawEvent.Texts.
+func WithTextRuns() Option {
+	return func(p *Parser) {
+		p.textRuns = true
+	}
+}
+
+// WithStrictSplit makes Parser fail with SplitError wrapping ErrAmbiguousSplit
+// instead of guessing when title and teacher of event cell can't be told apart,
+// e.g. when several segments that are not spaced initials precede type.
+func WithStrictSplit() Option {
+	return func(p *Parser) {
+		p.strictSplit = true
+	}
+}
+
+// WithRestoreYo makes Parser restore ё in title, teacher, location and note of events
+// using RestoreYo with given words. Type keywords are matched before restoration.
+func WithRestoreYo(words ...string) Option {
+	return func(p *Parser) {
+		p.yoDictionary = newYoDictionary(words)
+	}
+}
+
+// WithLocationSynonyms makes Parser replace location of events found in synonyms with its canonical label,
+// e.g. map[string]string{"ДК": "онлайн", "дистанционно": "онлайн", "Moodle": "онлайн"} collapses labels of remote location,
+// so that events are grouped by the label and Event.IsOnline detects them. Synonyms are matched against whole location
+// regardless of case and whitespace. Location as it is written in pdf content is available by Event.RawLocation.
+func WithLocationSynonyms(synonyms map[string]string) Option {
+	return func(p *Parser) {
+		p.locationSynonyms = newLocationSynonyms(synonyms)
+	}
+}
+
+// WithFieldNormalizer makes Parser replace value of string field with given name of Event struct, e.g. "Title"
+// or "Location", by result of fn for each parsed event, e.g. to fix known typos of titles.
+// Normalizers run after built-in normalization, i.e. after WithTrimTitleSuffixes, WithNormalizeTeacher,
+// WithLocationSynonyms and WithRestoreYo, in order of options. Names that aren't string fields of Event are ignored.
+func WithFieldNormalizer(field string, fn func(string) string) Option {
+	return func(p *Parser) {
+		p.fieldNormalizers = append(p.fieldNormalizers, fieldNormalizer{field, fn})
+	}
+}
+
+// WithTrimTitleSuffixes makes Parser trim course code in parentheses from the end of title of events,
+// e.g. "Физика (Б1.О.12)" becomes "Физика" with CourseCode "Б1.О.12". RawTitle keeps original title.
+func WithTrimTitleSuffixes() Option {
+	return func(p *Parser) {
+		p.trimTitleSuffixes = true
+	}
+}
+
+// WithFlippedY makes Parser convert Y coordinates of pdf content measured from top of page of given height
+// to Y coordinates measured from bottom of page, for pdf producers placing origin at top-left corner.
+// Without it, origin is expected at bottom-left corner, so larger Y is higher on page.
+func WithFlippedY(pageHeight float64) Option {
+	return func(p *Parser) {
+		p.pageHeight = pageHeight
+	}
+}
+
+// WithMeetingURLs makes Parser read link annotations of pdf content
+// and set MeetingURL of events to URIs of links placed within their cells.
+func WithMeetingURLs() Option {
+	return func(p *Parser) {
+		p.meetingURLs = true
+	}
+}
+
+// WithTimeout makes Parser stop parsing of pdf content with error wrapping context.DeadlineExceeded
+// when it takes longer than d. Reading of pdf file itself can't be interrupted, so it isn't limited.
+func WithTimeout(d time.Duration) Option {
+	return func(p *Parser) {
+		p.timeout = d
+	}
+}
+
+// WithTimeSlots makes Parser use given time slots of events table columns instead of built-in ones
+// and set PairNumber of event dates to number of slot starting at their start time.
+// Cell in column without configured slot fails to parse.
+func WithTimeSlots(slots ...TimeSlot) Option {
+	return func(p *Parser) {
+		p.config.times = newEventTimes(slots)
+	}
+}
+
+// WithTimeLayouts makes Parser parse times of time ranges written in cells, e.g. "12.09 1:45 PM-3:15 PM",
+// by layouts of time package tried in order, e.g. "15:04" for 24-hour clock and "3:04 PM" for 12-hour clock.
+// AM/PM marker is normalized to uppercase preceded by space, so "1:45pm" and "1:45 p.m." match "3:04 PM".
+// Without layouts, 24-hour layouts "15:04" and "15" are used. Time matching none of layouts fails to parse
+// with error listing them.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(p *Parser) {
+		if len(layouts) == 0 {
+			layouts = defaultTimeLayouts
+		}
+		p.config.timeLayouts = append([]string(nil), layouts...)
+	}
+}
+
+// WithSortByDate makes Parser sort parsed events using SortByDate.
+func WithSortByDate() Option {
+	return func(p *Parser) {
+		p.sortByDate = true
+	}
+}
+
+// WithDeduplicate makes Parser remove duplicate events using Deduplicate, i.e. Equal events such as class listed in two cells.
+func WithDeduplicate() Option {
+	return func(p *Parser) {
+		p.deduplicate = true
+	}
+}
+
+// WithNumeratorParity sets parity of numerator weeks ("числитель") of schedule, and denominator weeks ("знаменатель")
+// have the other parity. Weeks are counted from the first week of semester, which is odd week.
+// Without it numerator weeks are odd, i.e. the first week of semester is numerator week.
+func WithNumeratorParity(parity Parity) Option {
+	return func(p *Parser) {
+		p.config.numerator = parity
+	}
+}
+
+// WithCompactDates makes Parser remove spaces around ".", ":" and "-" between digits in dates of events,
+// e.g. "14. 09-28. 12" or "10: 15-11: 45" inserted by line joins of pdf content. Other fields are kept as they are.
+func WithCompactDates() Option {
+	return func(p *Parser) {
+		p.config.compactDates = true
+	}
+}
+
+// WithDatelessCells sets handling of cells without dates in brackets, e.g. informational cell "Классный час".
+// Cell without dates ends where the next cell starts, i.e. at line higher than its first line or in another column,
+// and it is formed into Event with Dateless flag or skipped. Without it cell is merged into the next cell
+// as DatelessMerge does. In pdf content whose cells start with dates, cells are always merged.
+func WithDatelessCells(handling DatelessCells) Option {
+	return func(p *Parser) {
+		p.datelessCells = handling
+	}
+}
+
+// WithCompoundCells makes Parser split compound cells holding two events of different types
+// with own teachers and locations and shared title and dates, e.g.
+// "Физика. Иванов И.И. лекции. 101. Петров П.П. лабораторные занятия. 202. [05.09]", into two events.
+func WithCompoundCells() Option {
+	return func(p *Parser) {
+		p.compoundCells = true
+	}
+}
+
+// WithKeepBracketsInLocation(true) makes Parser slice location and subgroup of events as it did before
+// boundary of dates was corrected, so that location may lose its last character or keep stray characters
+// preceding dates, e.g. "Locatio" of "Location [05.09]". WithKeepBracketsInLocation(false) is the default.
+//
+// Deprecated: WithKeepBracketsInLocation exists only for migration to corrected boundary and will be removed.
+func WithKeepBracketsInLocation(keep bool) Option {
+	return func(p *Parser) {
+		p.config.keepBrackets = keep
+	}
+}
@@ -0,0 +1,42 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+// flipY returns copy of content with Y coordinates measured from top of page of given height
+// converted to Y coordinates measured from bottom of page, which Parser expects.
+func flipY(content *reader.Content, height float64) *reader.Content {
+	flipped := &reader.Content{Texts: make([]pdf.Text, len(content.Texts)), PageStarts: content.PageStarts, Bookmarks: content.Bookmarks}
+	for i, text := range content.Texts {
+		text.Y = height - text.Y
+		flipped.Texts[i] = text
+	}
+	if content.Fills != nil {
+		flipped.Fills = make([]reader.Fill, len(content.Fills))
+		for i, fill := range content.Fills {
+			fill.Rect.Min.Y, fill.Rect.Max.Y = height-fill.Rect.Max.Y, height-fill.Rect.Min.Y
+			flipped.Fills[i] = fill
+		}
+	}
+	if content.Links != nil {
+		flipped.Links = make([]reader.Link, len(content.Links))
+		for i, link := range content.Links {
+			link.Rect.Min.Y, link.Rect.Max.Y = height-link.Rect.Max.Y, height-link.Rect.Min.Y
+			flipped.Links[i] = link
+		}
+	}
+	return flipped
+}
+
+// orient returns content with Y coordinates measured from bottom of page.
+// Content is returned as is unless Parser is set up with WithFlippedY.
+func (p *Parser) orient(content *reader.Content) *reader.Content {
+	if p.pageHeight <= 0 {
+		return content
+	}
+	return flipY(content, p.pageHeight)
+}
@@ -0,0 +1,61 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+func TestWithFlippedY(t *testing.T) {
+	// Y coordinates grow downward: header is at the top, Monday row is above Tuesday row.
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 40, Y: 30, S: "Курс: 2"},
+		{X: 5, Y: 100, S: "Понедельник"},
+		{X: 46, Y: 100, S: "Monday. лекции. Location. [04.09]"},
+		{X: 5, Y: 200, S: "Вторник"},
+		{X: 46, Y: 200, S: "Tuesday. лекции. Location. [05.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	schedule, err := NewParser(WithFlippedY(595)).parseText(&reader.Content{Texts: texts}, initialDate, "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	got := make([]string, len(schedule.Events))
+	for i, event := range schedule.Events {
+		got[i] = event.Title + " " + event.Weekday
+	}
+	if want := []string{"Monday Monday", "Tuesday Tuesday"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("titles and weekdays = %v, want %v", got, want)
+	}
+	if schedule.Course != 2 {
+		t.Errorf("Schedule.Course = %d, want %d", schedule.Course, 2)
+	}
+	if texts[0].Y != 30 {
+		t.Errorf("texts[0].Y = %v after parsing, want unmodified %v", texts[0].Y, 30)
+	}
+}
+
+func Test_flipY(t *testing.T) {
+	content := &reader.Content{
+		Texts: []pdf.Text{{X: 46, Y: 100, S: "T"}},
+		Fills: []reader.Fill{{Rect: pdf.Rect{Min: pdf.Point{X: 40, Y: 90}, Max: pdf.Point{X: 130, Y: 150}}}},
+	}
+	want := &reader.Content{
+		Texts: []pdf.Text{{X: 46, Y: 500, S: "T"}},
+		Fills: []reader.Fill{{Rect: pdf.Rect{Min: pdf.Point{X: 40, Y: 450}, Max: pdf.Point{X: 130, Y: 510}}}},
+	}
+	if got := flipY(content, 600); !reflect.DeepEqual(got, want) {
+		t.Errorf("flipY() = %v, want %v", got, want)
+	}
+}
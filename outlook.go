@@ -0,0 +1,67 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// outlookHeader is header of Outlook calendar import csv.
+var outlookHeader = []string{"Subject", "Start Date", "Start Time", "End Date", "End Time", "Location", "Description"}
+
+// csvOptions contains settings of ExportOutlookCSV.
+type csvOptions struct {
+	dateLayout string
+	timeLayout string
+}
+
+// CSVOption configures ExportOutlookCSV.
+type CSVOption func(*csvOptions)
+
+// WithCSVLayout sets layouts of dates and times written by ExportOutlookCSV
+// instead of US-style "01/02/2006" and "3:04 PM".
+func WithCSVLayout(dateLayout, timeLayout string) CSVOption {
+	return func(o *csvOptions) {
+		o.dateLayout = dateLayout
+		o.timeLayout = timeLayout
+	}
+}
+
+// ExportOutlookCSV writes events to w as csv in Outlook calendar import format.
+// Every occurrence of event is written as separate row in chronological order of occurrences of event. Cancelled events are skipped.
+func ExportOutlookCSV(events []Event, w io.Writer, opts ...CSVOption) error {
+	o := csvOptions{dateLayout: "01/02/2006", timeLayout: "3:04 PM"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(outlookHeader); err != nil {
+		return err
+	}
+	for i := range events {
+		event := &events[i]
+		if event.Cancelled {
+			continue
+		}
+		description := strings.Join(eventDetails(event), "\n")
+		for _, occurrence := range Occurrences(*event) {
+			record := []string{
+				event.Title,
+				occurrence.Start.Format(o.dateLayout),
+				occurrence.Start.Format(o.timeLayout),
+				occurrence.End.Format(o.dateLayout),
+				occurrence.End.Format(o.timeLayout),
+				event.Location,
+				description,
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
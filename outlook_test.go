@@ -0,0 +1,49 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestExportOutlookCSV(t *testing.T) {
+	events := []Event{
+		{Title: "Title", Teacher: "Teacher T.T.", Type: "lecture", Location: "101", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 12, 14, 0, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+	}
+
+	tests := []struct {
+		name string
+		opts []CSVOption
+		want string
+	}{
+		{
+			"US",
+			nil,
+			"Subject,Start Date,Start Time,End Date,End Time,Location,Description\n" +
+				"Title,09/05/2000,8:30 AM,09/05/2000,2:00 PM,101,\"lecture\nTeacher T.T.\"\n" +
+				"Title,09/12/2000,8:30 AM,09/12/2000,2:00 PM,101,\"lecture\nTeacher T.T.\"\n",
+		},
+		{
+			"CustomLayout",
+			[]CSVOption{WithCSVLayout("02.01.2006", "15:04")},
+			"Subject,Start Date,Start Time,End Date,End Time,Location,Description\n" +
+				"Title,05.09.2000,08:30,05.09.2000,14:00,101,\"lecture\nTeacher T.T.\"\n" +
+				"Title,12.09.2000,08:30,12.09.2000,14:00,101,\"lecture\nTeacher T.T.\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := ExportOutlookCSV(events, &buf, tt.opts...); err != nil {
+				t.Fatalf("ExportOutlookCSV() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("ExportOutlookCSV() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,36 +3,306 @@
 package scheduleparser
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
-	"github.com/ledongthuc/pdf"
 	"github.com/qsoulior/scheduleparser/internal/reader"
 )
 
-// parseText takes slice of pdf.Text,
-// parses content using getBodyText, getRawEvents and parseEvents,
-// returns parsed json content in bytes.
-func parseText(text []pdf.Text, initialDate time.Time) ([]byte, error) {
-	rawEvents := getRawEvents(text, initialDate)
-	events, err := parseEvents(rawEvents)
+// Parser parses schedule events from pdf content according to its options.
+// Parser is safe for concurrent use by multiple goroutines, e.g. by handlers of HTTP server:
+// options are applied only by NewParser and parsing doesn't modify Parser or share buffers between calls.
+// Functions set by WithErrorHandler, WithClock and WithFieldNormalizer are called concurrently then,
+// so they must be safe as well.
+type Parser struct {
+	legacyJSON      bool
+	occurrencesJSON bool
+	sourceFile      string
+	errorHandler    func(index int, raw RawEvent, err error)
+	clock           func() time.Time
+	highlight       bool
+	segments        bool
+	textRuns        bool
+	fieldNames      map[string]string
+	minConfidence   float64
+
+	maxEvents         int
+	maxCellLength     int
+	maxDateSpan       int
+	maxOccurrences    int
+	footnotesTop      float64
+	repairOCR         bool
+	rawDates          bool
+	normalizeTeacher  bool
+	trimTitleSuffixes bool
+	referenceYear     int
+	strictSplit       bool
+	yoDictionary      map[string]string
+	locationSynonyms  map[string]string
+	pageHeight        float64
+	meetingURLs       bool
+	timeout           time.Duration
+	sortByDate        bool
+	datelessCells     DatelessCells
+	compoundCells     bool
+	deduplicate       bool
+	pages             []int
+	fieldNormalizers  []fieldNormalizer
+	config            rawEventConfig // settings passed to raw events
+}
+
+// NewParser creates Parser, applies options to it and returns *Parser.
+func NewParser(opts ...Option) *Parser {
+	parser := &Parser{clock: time.Now}
+	for _, opt := range opts {
+		opt(parser)
+	}
+	return parser
+}
+
+// parseText takes pdf content,
+// parses it using getRawEvents, parseEvents and parseHeader,
+// sets source file of events, deduplicates and sorts them according to options and returns *Schedule.
+// Zero initial date is replaced with current time of Parser clock.
+// Parsing is stopped with error wrapping context.DeadlineExceeded when timeout of Parser is exceeded.
+func (p *Parser) parseText(content *reader.Content, initialDate time.Time, sourceFile string) (*Schedule, error) {
+	if len(content.Texts) == 0 {
+		return nil, ErrNoText
+	}
+	ctx := context.Background()
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	content = p.orient(content)
+	validity := getValidity(content.Texts)
+	if initialDate.IsZero() && validity != nil {
+		initialDate = validity.from
+	}
+	initialDate = p.initialDate(initialDate)
+	rawEvents, err := p.readRawEvents(content, initialDate, validity)
+	if err != nil {
+		return nil, fmt.Errorf("reading events error: %w", err)
+	}
+	events, err := p.parseEventsContext(ctx, rawEvents)
 	if err != nil {
 		return nil, fmt.Errorf("parsing error: %w", err)
 	}
+	for i := range events {
+		events[i].SourceFile = sourceFile
+	}
+	if p.deduplicate {
+		events = Deduplicate(events)
+	}
+	if p.sortByDate {
+		SortByDate(events)
+	}
+	schedule := &Schedule{InitialDate: initialDate, Events: events}
+	parseHeader(content.Texts, validity, schedule)
+	if schedule.ValidFrom.IsZero() {
+		if start, end := TermRange(events); !start.IsZero() {
+			schedule.ValidFrom = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+			schedule.ValidTo = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+		}
+	}
+	return schedule, nil
+}
+
+// readRawEvents forms raw events from content using getRawEvents, splits compound cells using splitCompounds
+// if WithCompoundCells is set and sets their details found elsewhere in content, e.g. weekdays of row labels.
+// Validity is validity period of schedule found in content or nil.
+func (p *Parser) readRawEvents(content *reader.Content, initialDate time.Time, validity *validity) ([]RawEvent, error) {
+	rawEvents, err := p.getRawEvents(content.Texts, content.PageStarts, initialDate)
+	if err != nil {
+		return nil, err
+	}
+	setPages(rawEvents, content.PageStarts)
+	p.repair(rawEvents)
+	if p.compoundCells {
+		rawEvents = splitCompounds(rawEvents)
+	}
+	setWeekdays(rawEvents, getWeekdayLabels(content.Texts))
+	setTimeLabels(rawEvents, getTimeLabels(content.Texts))
+	setGroups(rawEvents, getGroupLabels(content.Texts))
+	setBookmarkGroups(rawEvents, content)
+	if semester := getSemester(content.Texts, validity); semester != nil {
+		for i := range rawEvents {
+			rawEvents[i].semester = semester
+		}
+	}
+	if validity != nil {
+		for i := range rawEvents {
+			rawEvents[i].validity = validity
+		}
+	}
+	if p.footnotesTop > 0 {
+		footnotes := getFootnotes(content.Texts, p.footnotesTop)
+		for i := range rawEvents {
+			rawEvents[i].resolveFootnotes(footnotes)
+		}
+	}
+	if p.meetingURLs {
+		setMeetingURLs(rawEvents, content.Links, getCellWidth(content.Texts))
+	}
+	if p.highlight {
+		for i := range rawEvents {
+			rawEvents[i].highlighted = rawEvents[i].isHighlighted(content.Fills)
+		}
+	}
+	return rawEvents, nil
+}
+
+// setPages sets page of raw events to number of pdf page containing their first text.
+// Page of raw events remains 0 if content has no page starts.
+func setPages(rawEvents []RawEvent, pageStarts []reader.PageStart) {
+	for i := range rawEvents {
+		for _, start := range pageStarts {
+			if start.Index <= rawEvents[i].textIndex {
+				rawEvents[i].page = start.Number
+			}
+		}
+	}
+}
+
+// samePage reports whether pages are the same, considering unknown page 0 to be the same as any page.
+func samePage(a, b int) bool {
+	return a == 0 || b == 0 || a == b
+}
+
+// initialDate returns initial date of academic year of Parser reference year if it is set,
+// current time of Parser clock if initialDate is zero, or initialDate otherwise.
+func (p *Parser) initialDate(initialDate time.Time) time.Time {
+	if p.referenceYear != 0 {
+		return academicYearStart(p.referenceYear)
+	}
+	if initialDate.IsZero() {
+		return p.clock()
+	}
+	return initialDate
+}
+
+// repair repairs dates and location of raw events using repairCell if Parser repairs OCR errors.
+func (p *Parser) repair(rawEvents []RawEvent) {
+	if p.repairOCR {
+		for i := range rawEvents {
+			rawEvents[i].data = repairCell(rawEvents[i].data)
+		}
+	}
+}
+
+// readOptions returns options of reading pdf content required by Parser.
+func (p *Parser) readOptions() reader.Options {
+	return reader.Options{Fills: p.highlight, Links: p.meetingURLs, Pages: p.pages, Bookmarks: true}
+}
+
+// marshal returns json encoding of events in shape and with field names determined by options.
+func (p *Parser) marshal(events []Event) ([]byte, error) {
+	data, err := p.marshalShape(events)
+	if err != nil || len(p.fieldNames) == 0 {
+		return data, err
+	}
+	return renameFields(data, p.fieldNames)
+}
+
+// marshalShape returns json encoding of events in shape determined by options.
+func (p *Parser) marshalShape(events []Event) ([]byte, error) {
+	if p.legacyJSON {
+		legacyEvents := newLegacyEvents(events)
+		if p.occurrencesJSON {
+			for i := range legacyEvents {
+				legacyEvents[i].Occurrences = occurrenceDates(events[i])
+			}
+		}
+		return json.Marshal(legacyEvents)
+	}
+	if p.occurrencesJSON {
+		occurrencesEvents := make([]occurrencesEvent, len(events))
+		for i, event := range events {
+			occurrencesEvents[i] = occurrencesEvent{event, occurrenceDates(event)}
+		}
+		return json.Marshal(occurrencesEvents)
+	}
 	return json.Marshal(events)
 }
 
-// ParseFile reads slice of pdf.Text from input file using reader.ReadFile,
-// parses content using parseText and writes content bytes to output file.
-func ParseFile(inputPath string, outputPath string, initialDate time.Time) error {
-	text, err := reader.ReadFile(inputPath)
+// ParsePDF reads content of input file using reader.ReadContent,
+// parses it using parseText and returns *Schedule.
+// SourceFile of events is set to input file path.
+func (p *Parser) ParsePDF(inputPath string, initialDate time.Time) (*Schedule, error) {
+	file, err := os.Open(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseReader(file, fileInfo.Size(), initialDate, inputPath)
+}
+
+// ParseSchedule parses input file using ParsePDF with initial date determined by Parser,
+// i.e. by reference year or current time of clock, and returns *Schedule with header metadata and events.
+func (p *Parser) ParseSchedule(inputPath string) (*Schedule, error) {
+	return p.ParsePDF(inputPath, time.Time{})
+}
+
+// ParseReader reads content from r using reader.ReadContent,
+// parses it using parseText and returns *Schedule.
+func (p *Parser) ParseReader(r io.ReaderAt, size int64, initialDate time.Time) (*Schedule, error) {
+	return p.parseReader(r, size, initialDate, p.sourceFile)
+}
+
+// parseReader reads content from r and parses it using parseText.
+func (p *Parser) parseReader(r io.ReaderAt, size int64, initialDate time.Time, sourceFile string) (*Schedule, error) {
+	content, err := reader.ReadContent(r, size, p.readOptions())
+	if err != nil {
+		return nil, err
+	}
+	return p.parseText(content, initialDate, sourceFile)
+}
+
+// ParseDir parses every pdf file in directory using ParsePDF
+// and returns events of all files in order of file names.
+func (p *Parser) ParseDir(dirPath string, initialDate time.Time) ([]Event, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	events := make([]Event, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".pdf") {
+			continue
+		}
+		schedule, err := p.ParsePDF(filepath.Join(dirPath, entry.Name()), initialDate)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		events = append(events, schedule.Events...)
+	}
+	return events, nil
+}
+
+// ParseFile parses input file using ParsePDF and writes json bytes to output file.
+func (p *Parser) ParseFile(inputPath string, outputPath string, initialDate time.Time) error {
+	schedule, err := p.ParsePDF(inputPath, initialDate)
 	if err != nil {
 		return err
 	}
 
-	jsonBytes, err := parseText(text, initialDate)
+	jsonBytes, err := p.marshal(schedule.Events)
 	if err != nil {
 		return err
 	}
@@ -44,16 +314,48 @@ func ParseFile(inputPath string, outputPath string, initialDate time.Time) error
 	return nil
 }
 
-// ParseBytes gets slice of pdf.Text from content bytes using reader.ReadBytes,
-// parses content using parseText and returns content bytes.
-func ParseBytes(contentBytes []byte, initialDate time.Time) ([]byte, error) {
-	text, err := reader.ReadBytes(contentBytes)
-	if err != nil {
-		return nil, err
+// ParseBytes reads content from content bytes using reader.ReadContent,
+// parses it using parseText and returns json bytes.
+func (p *Parser) ParseBytes(contentBytes []byte, initialDate time.Time) ([]byte, error) {
+	if contentBytes == nil {
+		return nil, errors.New("contentBytes is nil")
 	}
-	jsonBytes, err := parseText(text, initialDate)
+	schedule, err := p.parseReader(bytes.NewReader(contentBytes), int64(len(contentBytes)), initialDate, p.sourceFile)
 	if err != nil {
 		return nil, err
 	}
-	return jsonBytes, nil
+	return p.marshal(schedule.Events)
+}
+
+// ParsePDF creates Parser with options and uses its ParsePDF.
+func ParsePDF(inputPath string, initialDate time.Time, opts ...Option) (*Schedule, error) {
+	return NewParser(opts...).ParsePDF(inputPath, initialDate)
+}
+
+// ParseSchedule creates Parser with options and uses its ParseSchedule.
+// It is the entry point for most uses, e.g.
+//
+//	ParseSchedule("input.pdf", WithReferenceYear(2023), WithNormalizeTeacher(), WithDeduplicate(), WithSortByDate())
+func ParseSchedule(inputPath string, opts ...Option) (*Schedule, error) {
+	return NewParser(opts...).ParseSchedule(inputPath)
+}
+
+// ParseReader creates Parser with options and uses its ParseReader.
+func ParseReader(r io.ReaderAt, size int64, initialDate time.Time, opts ...Option) (*Schedule, error) {
+	return NewParser(opts...).ParseReader(r, size, initialDate)
+}
+
+// ParseDir creates Parser with options and uses its ParseDir.
+func ParseDir(dirPath string, initialDate time.Time, opts ...Option) ([]Event, error) {
+	return NewParser(opts...).ParseDir(dirPath, initialDate)
+}
+
+// ParseFile creates Parser with options and uses its ParseFile.
+func ParseFile(inputPath string, outputPath string, initialDate time.Time, opts ...Option) error {
+	return NewParser(opts...).ParseFile(inputPath, outputPath, initialDate)
+}
+
+// ParseBytes creates Parser with options and uses its ParseBytes.
+func ParseBytes(contentBytes []byte, initialDate time.Time, opts ...Option) ([]byte, error) {
+	return NewParser(opts...).ParseBytes(contentBytes, initialDate)
 }
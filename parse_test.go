@@ -0,0 +1,607 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/pdftest"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+// testPDF returns bytes of pdf file containing single event with given title.
+func testPDF(title string) []byte {
+	return pdftest.Build([]pdf.Text{
+		{X: 46, Y: 500, S: title + ". Teacher T.T. лекции. Location."},
+		{X: 46, Y: 490, S: "[05.09-05.12 к.н.]"},
+	})
+}
+
+func TestParser_marshal(t *testing.T) {
+	events := []Event{{
+		Title: "Title",
+		Type:  "lecture",
+		Dates: []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce}},
+	}}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			"Nested",
+			nil,
+			`[{"title":"Title","teacher":"","type":"lecture","subgroup":"","location":"","dates":[{"date":"2000-09-05","start":"08:30","end":"10:10","week":36,"frequency":"once"}]}]`,
+		},
+		{
+			"Legacy",
+			[]Option{WithLegacyJSON()},
+			`[{"title":"Title","teacher":"","type":"lecture","subgroup":"","location":"","dates":[{"start":"2000-09-05T08:30:00+03:00","end":"2000-09-05T10:10:00+03:00","frequency":"once"}]}]`,
+		},
+		{
+			"RenamedTitle",
+			[]Option{WithFieldNames(map[string]string{"title": "name"})},
+			`[{"name":"Title","teacher":"","type":"lecture","subgroup":"","location":"","dates":[{"date":"2000-09-05","start":"08:30","end":"10:10","week":36,"frequency":"once"}]}]`,
+		},
+		{
+			"RenamedLegacy",
+			[]Option{WithLegacyJSON(), WithFieldNames(map[string]string{"title": "name", "start": "from"})},
+			`[{"name":"Title","teacher":"","type":"lecture","subgroup":"","location":"","dates":[{"start":"2000-09-05T08:30:00+03:00","end":"2000-09-05T10:10:00+03:00","frequency":"once"}]}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser(tt.opts...).marshal(events)
+			if err != nil {
+				t.Fatalf("Parser.marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Parser.marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithOccurrencesJSON(t *testing.T) {
+	events := []Event{{
+		Title: "Title",
+		Type:  "lecture",
+		Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 19, 10, 10, 0, 0, loc), Frequency: FrequencyEvery, Exceptions: []time.Time{time.Date(2000, 9, 12, 8, 30, 0, 0, loc)}},
+			{Start: time.Date(2000, 10, 3, 8, 30, 0, 0, loc), End: time.Date(2000, 10, 3, 10, 10, 0, 0, loc), Frequency: FrequencyOnce},
+		},
+	}}
+	want := []string{"2000-09-05", "2000-09-19", "2000-10-03"}
+
+	for _, opts := range [][]Option{{WithOccurrencesJSON()}, {WithOccurrencesJSON(), WithLegacyJSON()}} {
+		data, err := NewParser(opts...).marshal(events)
+		if err != nil {
+			t.Fatalf("Parser.marshal() error = %v", err)
+		}
+		var got []struct {
+			Dates []struct {
+				Frequency Frequency `json:"frequency"`
+			} `json:"dates"`
+			Occurrences []string `json:"occurrences"`
+		}
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("json.Unmarshal() error = %v", err)
+		}
+		if len(got) != 1 || len(got[0].Dates) != 2 || got[0].Dates[0].Frequency != FrequencyEvery {
+			t.Fatalf("Parser.marshal() = %s, want dates with frequency", data)
+		}
+		if !reflect.DeepEqual(got[0].Occurrences, want) {
+			t.Errorf("occurrences = %v, want %v", got[0].Occurrences, want)
+		}
+		if len(got[0].Occurrences) != OccurrenceCount(events[0]) {
+			t.Errorf("len(occurrences) = %d, want OccurrenceCount() = %d", len(got[0].Occurrences), OccurrenceCount(events[0]))
+		}
+	}
+}
+
+func TestParser_ParseDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"b.pdf":    testPDF("Second"),
+		"a.pdf":    testPDF("First"),
+		"note.txt": []byte("not a pdf"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	events, err := NewParser().ParseDir(dir, time.Date(2000, 8, 20, 0, 0, 0, 0, loc))
+	if err != nil {
+		t.Fatalf("Parser.ParseDir() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("len(Parser.ParseDir()) = %d, want %d", len(events), 2)
+	}
+	for i, want := range []struct{ title, sourceFile string }{
+		{"First", filepath.Join(dir, "a.pdf")},
+		{"Second", filepath.Join(dir, "b.pdf")},
+	} {
+		if events[i].Title != want.title || events[i].SourceFile != want.sourceFile {
+			t.Errorf("events[%d] = {%q, %q}, want {%q, %q}", i, events[i].Title, events[i].SourceFile, want.title, want.sourceFile)
+		}
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.pdf")
+	if err := os.WriteFile(path, pdftest.Build(loadFixture(t, filepath.Join("testdata", "schedule.json"))), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	schedule, err := ParseSchedule(path, WithReferenceYear(2000), WithDeduplicate(), WithSortByDate())
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+	if schedule.Faculty != "информационных технологий" || schedule.Course != 2 {
+		t.Errorf("ParseSchedule() faculty, course = %q, %d, want %q, %d", schedule.Faculty, schedule.Course, "информационных технологий", 2)
+	}
+	if want := time.Date(2000, 9, 5, 14, 30, 0, 0, loc); !schedule.UpdatedAt.Equal(want) {
+		t.Errorf("ParseSchedule() updated at = %v, want %v", schedule.UpdatedAt, want)
+	}
+	want := []struct{ title, location string }{{"Физика", "202"}, {"История", "101"}, {"История", "102"}}
+	if len(schedule.Events) != len(want) {
+		t.Fatalf("len(ParseSchedule().Events) = %d, want %d", len(schedule.Events), len(want))
+	}
+	for i, w := range want {
+		event := schedule.Events[i]
+		if event.Title != w.title || event.Location != w.location || event.SourceFile != path {
+			t.Errorf("events[%d] = {%q, %q, %q}, want {%q, %q, %q}", i, event.Title, event.Location, event.SourceFile, w.title, w.location, path)
+		}
+		if year := event.Dates[0].Start.Year(); year != 2000 {
+			t.Errorf("events[%d] year = %d, want %d", i, year, 2000)
+		}
+	}
+}
+
+func TestParser_concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schedule.pdf")
+	if err := os.WriteFile(path, pdftest.Build(loadFixture(t, filepath.Join("testdata", "schedule.json"))), 0644); err != nil {
+		t.Fatal(err)
+	}
+	parser := NewParser(WithNormalizeTeacher(), WithRestoreYo(), WithTimeSlots(TimeSlot{8 * time.Hour, 9 * time.Hour}, TimeSlot{9 * time.Hour, 10 * time.Hour},
+		TimeSlot{10 * time.Hour, 11 * time.Hour}, TimeSlot{11 * time.Hour, 12 * time.Hour}, TimeSlot{12 * time.Hour, 13 * time.Hour}), WithDeduplicate(), WithSortByDate())
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+	want, err := parser.ParsePDF(path, initialDate)
+	if err != nil {
+		t.Fatalf("Parser.ParsePDF() error = %v", err)
+	}
+
+	const n = 8
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			got, err := parser.ParsePDF(path, initialDate)
+			if err == nil && !reflect.DeepEqual(got, want) {
+				err = fmt.Errorf("Parser.ParsePDF() = %v, want %v", got, want)
+			}
+			errs <- err
+		}()
+	}
+	for i := 0; i < n; i++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestParser_ParseReader(t *testing.T) {
+	content := testPDF("Title")
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{"WithoutSourceFile", nil, ""},
+		{"WithSourceFile", []Option{WithSourceFile("input.pdf")}, "input.pdf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewParser(tt.opts...).ParseReader(bytes.NewReader(content), int64(len(content)), initialDate)
+			if err != nil {
+				t.Fatalf("Parser.ParseReader() error = %v", err)
+			}
+			if !schedule.InitialDate.Equal(initialDate) {
+				t.Errorf("Schedule.InitialDate = %v, want %v", schedule.InitialDate, initialDate)
+			}
+			if events := schedule.Events; len(events) != 1 || events[0].SourceFile != tt.want {
+				t.Errorf("Schedule.Events = %v, want single event with SourceFile %q", events, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_ParseReader_noText(t *testing.T) {
+	content := pdftest.Build([]pdf.Text{})
+	if _, err := NewParser().ParseReader(bytes.NewReader(content), int64(len(content)), time.Time{}); !errors.Is(err, ErrNoText) {
+		t.Errorf("Parser.ParseReader() error = %v, want %v", err, ErrNoText)
+	}
+	if _, err := NewParser().parseText(&reader.Content{Texts: []pdf.Text{}}, time.Time{}, ""); !errors.Is(err, ErrNoText) {
+		t.Errorf("Parser.parseText() error = %v, want %v", err, ErrNoText)
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	content := testPDF("Title")
+	now := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+	clock := func() time.Time { return now }
+
+	for _, initialDate := range []time.Time{{}, now} {
+		schedule, err := NewParser(WithClock(clock)).ParseReader(bytes.NewReader(content), int64(len(content)), initialDate)
+		if err != nil {
+			t.Fatalf("Parser.ParseReader() error = %v", err)
+		}
+		if !schedule.InitialDate.Equal(now) {
+			t.Errorf("Schedule.InitialDate = %v, want %v", schedule.InitialDate, now)
+		}
+		if year := schedule.Events[0].Dates[0].Start.Year(); year != 2000 {
+			t.Errorf("Dates[0].Start.Year() = %d, want %d", year, 2000)
+		}
+	}
+}
+
+func TestWithReferenceYear(t *testing.T) {
+	content := pdftest.Build([]pdf.Text{
+		{X: 46, Y: 500, S: "Title. Teacher T.T. лекции. Location."},
+		{X: 46, Y: 490, S: "[05.09, 12.02]"},
+	})
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		initialDate time.Time
+		want        [2]int
+	}{
+		{"InitialDate", nil, time.Date(2010, 8, 20, 0, 0, 0, 0, loc), [2]int{2010, 2011}},
+		{"ReferenceYear", []Option{WithReferenceYear(2000)}, time.Time{}, [2]int{2000, 2001}},
+		{"ReferenceYearOverInitialDate", []Option{WithReferenceYear(2000)}, time.Date(2010, 8, 20, 0, 0, 0, 0, loc), [2]int{2000, 2001}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewParser(tt.opts...).ParseReader(bytes.NewReader(content), int64(len(content)), tt.initialDate)
+			if err != nil {
+				t.Fatalf("Parser.ParseReader() error = %v", err)
+			}
+			dates := schedule.Events[0].Dates
+			if got := [2]int{dates[0].Start.Year(), dates[1].Start.Year()}; got != tt.want {
+				t.Errorf("years of dates = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithPages(t *testing.T) {
+	pages := make([][]pdf.Text, 3)
+	for i := range pages {
+		pages[i] = []pdf.Text{
+			{X: 46, Y: 500, S: fmt.Sprintf("Page%d. Teacher T.T. лекции. Location.", i+1)},
+			{X: 46, Y: 490, S: "[05.09-05.12 к.н.]"},
+		}
+	}
+	content := pdftest.Build(pages...)
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    []string
+		wantErr bool
+	}{
+		{"FirstByDefault", nil, []string{"Page1"}, false},
+		{"Second", []Option{WithPages(2)}, []string{"Page2"}, false},
+		{"Several", []Option{WithPages(3, 1)}, []string{"Page3", "Page1"}, false},
+		{"OutOfRange", []Option{WithPages(4)}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewParser(tt.opts...).ParseReader(bytes.NewReader(content), int64(len(content)), time.Time{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parser.ParseReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			titles := make([]string, len(schedule.Events))
+			for i, event := range schedule.Events {
+				titles[i] = event.Title
+			}
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("titles = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithHighlight(t *testing.T) {
+	content := pdftest.BuildPages(pdftest.Page{
+		Texts: []pdf.Text{
+			{X: 46, Y: 500, S: "Highlighted. лекции. Location. [05.09]"},
+			{X: 139, Y: 500, S: "Plain. лекции. Location. [05.09]"},
+			{X: 233, Y: 500, S: "White. лекции. Location. [05.09]"},
+		},
+		Graphics: "1 1 0 rg 42 480 90 40 re f 0 g 135 480 90 1 re f 1 g 230 480 90 40 re f",
+	})
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []bool
+	}{
+		{"WithoutOption", nil, []bool{false, false, false}},
+		{"WithOption", []Option{WithHighlight()}, []bool{true, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewParser(tt.opts...).ParseReader(bytes.NewReader(content), int64(len(content)), initialDate)
+			if err != nil {
+				t.Fatalf("Parser.ParseReader() error = %v", err)
+			}
+			got := make([]bool, len(schedule.Events))
+			for i, event := range schedule.Events {
+				got[i] = event.Highlighted
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Highlighted = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithHighlight_pages(t *testing.T) {
+	content := pdftest.BuildPages(
+		pdftest.Page{
+			Texts:    []pdf.Text{{X: 46, Y: 500, S: "First. лекции. Location. [05.09]"}},
+			Graphics: "1 1 0 rg 42 480 90 40 re f",
+		},
+		pdftest.Page{
+			Texts: []pdf.Text{{X: 46, Y: 500, S: "Second. лекции. Location. [05.09]"}},
+		},
+	)
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	schedule, err := NewParser(WithHighlight(), WithPages(1, 2)).ParseReader(bytes.NewReader(content), int64(len(content)), initialDate)
+	if err != nil {
+		t.Fatalf("Parser.ParseReader() error = %v", err)
+	}
+	got := make([]bool, len(schedule.Events))
+	for i, event := range schedule.Events {
+		got[i] = event.Highlighted
+	}
+	if want := []bool{true, false}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Highlighted = %v, want %v", got, want)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for i := 0; i < 10000; i++ {
+		for _, r := range "Title. Teacher T.T. лекции. Location. [05.09-05.12 к.н.]" {
+			texts = append(texts, pdf.Text{X: 46, Y: float64(500 - i%400), S: string(r)})
+		}
+	}
+	content := &reader.Content{Texts: texts}
+
+	if _, err := NewParser(WithTimeout(time.Nanosecond)).parseText(content, time.Time{}, ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Parser.parseText() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if _, err := NewParser(WithTimeout(time.Minute)).parseText(content, time.Time{}, ""); err != nil {
+		t.Errorf("Parser.parseText() error = %v", err)
+	}
+}
+
+func TestWithCompactDates(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 500, S: "First. лекции. Location. [14. 09-28. 12 к.н.]"},
+		{X: 139, Y: 500, S: "Second. лекции. Location. [05.09 10: 15-11: 45]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+	content := &reader.Content{Texts: texts}
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	if _, err := NewParser().parseText(content, initialDate, ""); !errors.Is(err, ErrDateParse) {
+		t.Errorf("Parser.parseText() error = %v, want %v", err, ErrDateParse)
+	}
+	schedule, err := NewParser(WithCompactDates()).parseText(content, initialDate, "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	want := [][]EventDate{
+		{{Start: time.Date(2000, 9, 14, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 28, 10, 10, 0, 0, loc), Frequency: FrequencyEvery}},
+		{{Start: time.Date(2000, 9, 5, 10, 15, 0, 0, loc), End: time.Date(2000, 9, 5, 11, 45, 0, 0, loc), Frequency: FrequencyOnce}},
+	}
+	for i, event := range schedule.Events {
+		if !reflect.DeepEqual(event.Dates, want[i]) {
+			t.Errorf("events[%d].Dates = %v, want %v", i, event.Dates, want[i])
+		}
+		if event.Location != "Location" {
+			t.Errorf("events[%d].Location = %q, want %q", i, event.Location, "Location")
+		}
+	}
+}
+
+func TestWithKeepBracketsInLocation(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 500, S: "First. лекции. Location. [05.09]"},
+		{X: 139, Y: 500, S: "Second. лекции. Location [05.09]"},
+		{X: 233, Y: 500, S: "Third. лекции. Location.[05.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+	content := &reader.Content{Texts: texts}
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{"Default", nil, []string{"Location", "Location", "Location"}},
+		{"Corrected", []Option{WithKeepBracketsInLocation(false)}, []string{"Location", "Location", "Location"}},
+		{"Kept", []Option{WithKeepBracketsInLocation(true)}, []string{"Location", "Locatio", "Locatio"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewParser(tt.opts...).parseText(content, initialDate, "")
+			if err != nil {
+				t.Fatalf("Parser.parseText() error = %v", err)
+			}
+			got := make([]string, len(schedule.Events))
+			for i, event := range schedule.Events {
+				got[i] = event.Location
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("locations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTimeSlots(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 500, S: "First. лекции. Location. [05.09]"},
+		{X: 139, Y: 500, S: "Second. лекции. Location. [05.09]"},
+		{X: 233, Y: 500, S: "Third. лекции. Location. [05.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+	content := &reader.Content{Texts: texts}
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+	slots := []TimeSlot{
+		{9 * time.Hour, 10*time.Hour + 30*time.Minute},
+		{10*time.Hour + 45*time.Minute, 12*time.Hour + 15*time.Minute},
+		{13 * time.Hour, 14*time.Hour + 30*time.Minute},
+	}
+
+	schedule, err := NewParser(WithTimeSlots(slots...)).parseText(content, initialDate, "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	for i, event := range schedule.Events {
+		date := event.Dates[0]
+		if start := time.Duration(date.Start.Hour())*time.Hour + time.Duration(date.Start.Minute())*time.Minute; start != slots[i].Start {
+			t.Errorf("events[%d] start = %v, want %v", i, start, slots[i].Start)
+		}
+		if date.PairNumber != i+1 {
+			t.Errorf("events[%d] PairNumber = %d, want %d", i, date.PairNumber, i+1)
+		}
+	}
+
+	schedule, err = NewParser().parseText(content, initialDate, "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	if got := schedule.Events[0].Dates[0].PairNumber; got != 0 {
+		t.Errorf("PairNumber = %d without WithTimeSlots, want 0", got)
+	}
+}
+
+func TestParser_parseText_bookmarks(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 560, S: "Группа ИВТ-103"},
+		{X: 46, Y: 500, S: "First. лекции. Location. [05.09]"},
+		{X: 46, Y: 570, S: "Группа ИВТ-103"},
+		{X: 46, Y: 500, S: "Second. лекции. Location. [06.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+	second := len([]rune("Группа ИВТ-103First. лекции. Location. [05.09]"))
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name      string
+		bookmarks []reader.Bookmark
+		want      []string
+	}{
+		{"Bookmarks", []reader.Bookmark{{Title: "Группа ИВТ-101", Page: 1}, {Title: " группа: ИВТ-102 ", Page: 2}}, []string{"ИВТ-101", "ИВТ-102"}},
+		{"SingleBookmark", []reader.Bookmark{{Title: "Группа ИВТ-101", Page: 1}}, []string{"ИВТ-101", "ИВТ-101"}},
+		{"SecondPageBookmark", []reader.Bookmark{{Title: "Группа ИВТ-102", Page: 2}}, []string{"ИВТ-103", "ИВТ-102"}},
+		{"NoBookmarks", nil, []string{"ИВТ-103", "ИВТ-103"}},
+		{"NonGroupBookmarks", []reader.Bookmark{{Title: "Расписание", Page: 1}, {Title: "Стр. 2", Page: 2}}, []string{"ИВТ-103", "ИВТ-103"}},
+		{"SamePage", []reader.Bookmark{{Title: "Группа ИВТ-101", Page: 1}, {Title: "Группа ИВТ-102", Page: 1}}, []string{"ИВТ-103", "ИВТ-103"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := &reader.Content{
+				Texts:      texts,
+				PageStarts: []reader.PageStart{{Number: 1, Index: 0}, {Number: 2, Index: second}},
+				Bookmarks:  tt.bookmarks,
+			}
+			schedule, err := NewParser().parseText(content, initialDate, "")
+			if err != nil {
+				t.Fatalf("Parser.parseText() error = %v", err)
+			}
+			if len(schedule.Events) != len(tt.want) {
+				t.Fatalf("len(events) = %d, want %d", len(schedule.Events), len(tt.want))
+			}
+			for i, event := range schedule.Events {
+				if event.Group != tt.want[i] {
+					t.Errorf("events[%d].Group = %q, want %q", i, event.Group, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParser_parseText_bookmarksMultiGroupPage(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 46, Y: 560, S: "Группа ИВТ-103"},
+		{X: 300, Y: 560, S: "Группа ИВТ-104"},
+		{X: 46, Y: 500, S: "First. лекции. Location. [05.09]"},
+		{X: 300, Y: 500, S: "Second. лекции. Location. [05.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+	content := &reader.Content{
+		Texts:      texts,
+		PageStarts: []reader.PageStart{{Number: 1, Index: 0}},
+		Bookmarks:  []reader.Bookmark{{Title: "Группа ИВТ-101", Page: 1}},
+	}
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+
+	schedule, err := NewParser().parseText(content, initialDate, "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	got := make([]string, len(schedule.Events))
+	for i, event := range schedule.Events {
+		got[i] = event.Group
+	}
+	if want := []string{"ИВТ-103", "ИВТ-104"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("groups = %q, want %q", got, want)
+	}
+}
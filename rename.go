@@ -0,0 +1,66 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// renameFields takes json array of objects and returns it with keys of objects renamed by names,
+// e.g. "title" to "name". Keys that are not in names and nested objects are kept as they are,
+// and order of keys is preserved.
+func renameFields(data []byte, names map[string]string) ([]byte, error) {
+	var objects []json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, object := range objects {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := renameObjectFields(&buf, object, names); err != nil {
+			return nil, fmt.Errorf("objects[%d]: %w", i, err)
+		}
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// renameObjectFields writes json object to buf with keys renamed by names.
+func renameObjectFields(buf *bytes.Buffer, object json.RawMessage, names map[string]string) error {
+	decoder := json.NewDecoder(bytes.NewReader(object))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return fmt.Errorf("object is expected")
+	}
+	buf.WriteByte('{')
+	for first := true; decoder.More(); first = false {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key := token.(string)
+		if name, ok := names[key]; ok {
+			key = name
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		buf.Write(keyBytes)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return nil
+}
@@ -0,0 +1,227 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Schedule contains events parsed from pdf content and metadata of parsing.
+type Schedule struct {
+	// InitialDate is the effective date used to determine year of event dates.
+	InitialDate time.Time `json:"initialDate"`
+	Faculty     string    `json:"faculty"`
+	Direction   string    `json:"direction"`
+	Course      int       `json:"course"`
+	// ValidFrom and ValidTo are first and last dates of validity period of schedule at midnight,
+	// e.g. "действует с 01.09.2024 по 31.12.2024". They are derived from dates of events by TermRange
+	// when header has no validity period.
+	ValidFrom time.Time `json:"validFrom,omitempty"`
+	ValidTo   time.Time `json:"validTo,omitempty"`
+	// UpdatedAt is last-updated datetime printed in header or footer, e.g. "обновлено 05.09.2024",
+	// or zero time if it isn't printed.
+	UpdatedAt time.Time `json:"updatedAt"`
+	Events    []Event   `json:"events"`
+}
+
+// scheduleJSON is json shape of Schedule with zero validity period omitted,
+// since omitempty has no effect on time.Time fields.
+type scheduleJSON struct {
+	InitialDate time.Time  `json:"initialDate"`
+	Faculty     string     `json:"faculty"`
+	Direction   string     `json:"direction"`
+	Course      int        `json:"course"`
+	ValidFrom   *time.Time `json:"validFrom,omitempty"`
+	ValidTo     *time.Time `json:"validTo,omitempty"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	Events      []Event    `json:"events"`
+}
+
+// MarshalJSON encodes Schedule omitting ValidFrom and ValidTo if they are zero.
+func (schedule Schedule) MarshalJSON() ([]byte, error) {
+	s := scheduleJSON{
+		InitialDate: schedule.InitialDate,
+		Faculty:     schedule.Faculty,
+		Direction:   schedule.Direction,
+		Course:      schedule.Course,
+		UpdatedAt:   schedule.UpdatedAt,
+		Events:      schedule.Events,
+	}
+	if !schedule.ValidFrom.IsZero() {
+		s.ValidFrom = &schedule.ValidFrom
+	}
+	if !schedule.ValidTo.IsZero() {
+		s.ValidTo = &schedule.ValidTo
+	}
+	return json.Marshal(s)
+}
+
+// firstStart returns the earliest start datetime of event dates
+// and false if event has no dates.
+func firstStart(event *Event) (time.Time, bool) {
+	if len(event.Dates) == 0 {
+		return time.Time{}, false
+	}
+	start := event.Dates[0].Start
+	for _, date := range event.Dates[1:] {
+		if date.Start.Before(start) {
+			start = date.Start
+		}
+	}
+	return start, true
+}
+
+// TermRange returns the earliest start datetime and the latest end datetime of dates of events,
+// e.g. to bound calendar view when header has no semester range. Zero datetimes are ignored.
+// Both returned datetimes are zero if events have no dates.
+func TermRange(events []Event) (start, end time.Time) {
+	for i := range events {
+		for _, date := range events[i].Dates {
+			if !date.Start.IsZero() && (start.IsZero() || date.Start.Before(start)) {
+				start = date.Start
+			}
+			if !date.End.IsZero() && (end.IsZero() || date.End.After(end)) {
+				end = date.End
+			}
+		}
+	}
+	return start, end
+}
+
+// SortByDate sorts events in place by the earliest start datetime of their dates.
+// Events without dates are placed at the end. Order of equal events is kept.
+func SortByDate(events []Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		si, oki := firstStart(&events[i])
+		sj, okj := firstStart(&events[j])
+		if oki != okj {
+			return oki
+		}
+		return si.Before(sj)
+	})
+}
+
+// Deduplicate returns events without duplicates, i.e. events Equal to preceding event,
+// or duplicates of preceding event by ComputeID of WithIDOptions or by comparator of WithComparator.
+// The first of duplicates is kept and order of events is kept. Returned events are clones of events.
+func Deduplicate(events []Event, opts ...DuplicateOption) []Event {
+	seen := newDuplicates(opts)
+	unique := make([]Event, 0, len(events))
+	for _, event := range events {
+		if _, ok := seen.find(event); ok {
+			continue
+		}
+		seen.add(event, len(unique))
+		unique = append(unique, event.Clone())
+	}
+	return unique
+}
+
+// MergeSchedules merges events of two schedules of the same group, e.g. two terms.
+// Returned events are clones of events of a and b.
+// Events that are Equal, or duplicates by ComputeID of WithIDOptions or by comparator of WithComparator,
+// are considered duplicates and only one of them is kept. When duplicates differ in details
+// (e.g. location of events with equal ComputeID), the event of b wins, since b is considered to be the later schedule. Result is sorted using SortByDate.
+func MergeSchedules(a, b []Event, opts ...DuplicateOption) []Event {
+	events := make([]Event, 0, len(a)+len(b))
+	seen := newDuplicates(opts)
+	for _, schedule := range [][]Event{a, b} {
+		for _, event := range schedule {
+			if i, ok := seen.find(event); ok {
+				events[i] = event.Clone()
+				continue
+			}
+			seen.add(event, len(events))
+			events = append(events, event.Clone())
+		}
+	}
+	SortByDate(events)
+	return events
+}
+
+// ByGroup returns events of schedule by their student groups.
+// Events without group are returned with empty group. Returned events are clones of events of schedule.
+func (schedule *Schedule) ByGroup() map[string][]Event {
+	groups := make(map[string][]Event)
+	for _, event := range schedule.Events {
+		groups[event.Group] = append(groups[event.Group], event.Clone())
+	}
+	return groups
+}
+
+// Keys of GroupByFrequency for every other week dates of even and odd weeks.
+// They are never set as Frequency of EventDate.
+const (
+	FrequencyThroughoutEven Frequency = "throughout-even" // every other week of even weeks
+	FrequencyThroughoutOdd  Frequency = "throughout-odd"  // every other week of odd weeks
+)
+
+// GroupByFrequency returns events by frequency of their dates, e.g. to tell weekly classes from single ones.
+// Event with dates of several frequencies is returned under each of them with its dates of that frequency only,
+// and events without dates are omitted. Returned events are clones of events in order of events.
+// Every other week dates of even or odd weeks are grouped under FrequencyThroughoutEven or FrequencyThroughoutOdd.
+// Every other week dates without parity stay under FrequencyThroughout, since which weeks are even
+// depends on start of semester that isn't known from events.
+func GroupByFrequency(events []Event) map[Frequency][]Event {
+	groups := make(map[Frequency][]Event)
+	for _, event := range events {
+		dates := make(map[Frequency][]EventDate)
+		frequencies := make([]Frequency, 0, 1)
+		for _, date := range event.Dates {
+			frequency := date.Frequency
+			if frequency == FrequencyThroughout {
+				switch date.Parity {
+				case ParityEven:
+					frequency = FrequencyThroughoutEven
+				case ParityOdd:
+					frequency = FrequencyThroughoutOdd
+				}
+			}
+			if _, ok := dates[frequency]; !ok {
+				frequencies = append(frequencies, frequency)
+			}
+			dates[frequency] = append(dates[frequency], date.Clone())
+		}
+		for _, frequency := range frequencies {
+			clone := event.Clone()
+			clone.Dates = dates[frequency]
+			groups[frequency] = append(groups[frequency], clone)
+		}
+	}
+	return groups
+}
+
+// CollapseSubgroups merges events that differ only by subgroup into single event, e.g. two subgroup rows of one class.
+// Events are merged only when their titles, types, teachers, locations and dates are equal.
+// Merged event lists subgroups in Subgroups and joins them in Subgroup. Order of events is kept.
+// Returned events are clones, so events are left unmodified.
+func CollapseSubgroups(events []Event) []Event {
+	collapsed := make([]Event, 0, len(events))
+	indexes := make(map[string]int)
+	for _, event := range events {
+		if event.Subgroup == "" {
+			collapsed = append(collapsed, event.Clone())
+			continue
+		}
+		common := event
+		common.Subgroup = ""
+		key := ComputeID(common) + "\x00" + event.Location
+		i, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(collapsed)
+			collapsed = append(collapsed, event.Clone())
+			continue
+		}
+		merged := &collapsed[i]
+		if merged.Subgroups == nil {
+			merged.Subgroups = []string{merged.Subgroup}
+		}
+		merged.Subgroups = append(merged.Subgroups, event.Subgroup)
+		merged.Subgroup = strings.Join(merged.Subgroups, ", ")
+		merged.SubgroupNumber = 0
+	}
+	return collapsed
+}
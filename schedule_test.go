@@ -0,0 +1,193 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSortByDate(t *testing.T) {
+	events := []Event{
+		{Title: "WithoutDates"},
+		{Title: "Second", Dates: []EventDate{{Start: time.Date(2000, 9, 12, 8, 30, 0, 0, loc)}, {Start: time.Date(2000, 9, 6, 8, 30, 0, 0, loc)}}},
+		{Title: "First", Dates: []EventDate{{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc)}}},
+	}
+	SortByDate(events)
+
+	want := []string{"First", "Second", "WithoutDates"}
+	for i, event := range events {
+		if event.Title != want[i] {
+			t.Errorf("events[%d].Title = %q, want %q", i, event.Title, want[i])
+		}
+	}
+}
+
+func TestTermRange(t *testing.T) {
+	events := []Event{
+		{Title: "Lecture", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+		}},
+		{Title: "Without dates"},
+		{Title: "Seminar", Dates: []EventDate{
+			{Start: time.Date(2000, 9, 1, 12, 20, 0, 0, loc), End: time.Date(2000, 9, 1, 14, 0, 0, 0, loc), Frequency: FrequencyOnce},
+			{Start: time.Date(2000, 12, 26, 12, 20, 0, 0, loc), End: time.Date(2000, 12, 26, 14, 0, 0, 0, loc), Frequency: FrequencyOnce},
+		}},
+		{Title: "Zero", Dates: []EventDate{{}}},
+	}
+
+	start, end := TermRange(events)
+	if want := time.Date(2000, 9, 1, 12, 20, 0, 0, loc); !start.Equal(want) {
+		t.Errorf("TermRange() start = %v, want %v", start, want)
+	}
+	if want := time.Date(2000, 12, 26, 14, 0, 0, 0, loc); !end.Equal(want) {
+		t.Errorf("TermRange() end = %v, want %v", end, want)
+	}
+	if start, end := TermRange(nil); !start.IsZero() || !end.IsZero() {
+		t.Errorf("TermRange() = %v, %v, want zero datetimes", start, end)
+	}
+}
+
+func TestMergeSchedules(t *testing.T) {
+	fall := Event{Title: "Fall", Type: "lecture", Location: "Location", Dates: []EventDate{
+		{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+	}}
+	spring := Event{Title: "Spring", Type: "lecture", Location: "Location", Dates: []EventDate{
+		{Start: time.Date(2001, 2, 6, 8, 30, 0, 0, loc), End: time.Date(2001, 5, 29, 10, 10, 0, 0, loc), Frequency: FrequencyEvery},
+	}}
+	relocated := fall
+	relocated.Location = "Other"
+
+	tests := []struct {
+		name string
+		a    []Event
+		b    []Event
+		opts []DuplicateOption
+		want []Event
+	}{
+		{"Concatenate", []Event{spring}, []Event{fall}, nil, []Event{fall, spring}},
+		{"Duplicate", []Event{fall, spring}, []Event{fall}, nil, []Event{fall, spring}},
+		{"DifferentFieldsKept", []Event{fall}, []Event{relocated, spring}, nil, []Event{fall, relocated, spring}},
+		{"ConflictPrefersLater", []Event{fall}, []Event{relocated, spring}, []DuplicateOption{WithIDOptions()}, []Event{relocated, spring}},
+		{"Empty", nil, nil, nil, []Event{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeSchedules(tt.a, tt.b, tt.opts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergeSchedules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollapseSubgroups(t *testing.T) {
+	date := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery}
+	events := []Event{
+		{Title: "Lab", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "1 подгруппа", SubgroupNumber: 1, Location: "101", Dates: []EventDate{date}},
+		{Title: "Lecture", Teacher: "Teacher T.T.", Type: "lecture", Location: "201", Dates: []EventDate{date}},
+		{Title: "Lab", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "2 подгруппа", SubgroupNumber: 2, Location: "101", Dates: []EventDate{date}},
+		{Title: "Lab", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "3 подгруппа", SubgroupNumber: 3, Location: "102", Dates: []EventDate{date}},
+	}
+	want := []Event{
+		{Title: "Lab", Teacher: "Teacher T.T.", Type: "lab", Subgroup: "1 подгруппа, 2 подгруппа", Subgroups: []string{"1 подгруппа", "2 подгруппа"}, Location: "101", Dates: []EventDate{date}},
+		events[1],
+		events[3],
+	}
+	got := CollapseSubgroups(events)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollapseSubgroups() = %v, want %v", got, want)
+	}
+	got[0].Dates[0].Frequency = FrequencyOnce
+	if !reflect.DeepEqual(events[0].Dates[0], date) {
+		t.Errorf("events[0].Dates[0] = %v after mutating result, want %v", events[0].Dates[0], date)
+	}
+}
+
+func TestSchedule_ByGroup(t *testing.T) {
+	schedule := &Schedule{Events: []Event{{Title: "First", Group: "ИВТ-101"}, {Title: "Second", Group: "ИВТ-102"}, {Title: "Third", Group: "ИВТ-101"}}}
+	want := map[string][]Event{
+		"ИВТ-101": {schedule.Events[0], schedule.Events[2]},
+		"ИВТ-102": {schedule.Events[1]},
+	}
+	if got := schedule.ByGroup(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Schedule.ByGroup() = %v, want %v", got, want)
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	date := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce}
+	events := []Event{
+		{Title: "Lecture", Teacher: "Teacher T.T.", Location: "101", Dates: []EventDate{date}},
+		{Title: "Seminar", Teacher: "Teacher T.T.", Location: "102", Dates: []EventDate{date}},
+		{Title: "Lecture", Teacher: "Teacher T.T.", Location: "101", Dates: []EventDate{date}},
+		{Title: "Lecture", Teacher: "Teacher T.T.", Location: "201", Dates: []EventDate{date}},
+	}
+	want := []Event{events[0], events[1], events[3]}
+	if got := Deduplicate(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("Deduplicate() = %v, want %v", got, want)
+	}
+	if got, want := Deduplicate(events, WithIDOptions()), events[:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Deduplicate(WithIDOptions()) = %v, want %v", got, want)
+	}
+
+	prefixed := []Event{events[0], {Title: "Lecture", Teacher: "доц. Teacher T.T.", Location: "201", Dates: []EventDate{date}}}
+	if got := Deduplicate(prefixed); len(got) != 2 {
+		t.Errorf("len(Deduplicate()) = %d, want %d", len(got), 2)
+	}
+	if got, want := Deduplicate(prefixed, WithIDOptions(WithoutDegrees())), prefixed[:1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Deduplicate(WithIDOptions()) = %v, want %v", got, want)
+	}
+}
+
+func TestWithComparator(t *testing.T) {
+	date := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce}
+	a := []Event{
+		{Title: "Lecture", Teacher: "Teacher T.T.", Location: "101", Dates: []EventDate{date}},
+		{Title: "Lecture", Teacher: "Other O.O.", Location: "102", Dates: []EventDate{date}},
+	}
+	b := []Event{{Title: "Lecture", Teacher: "Substitute S.S.", Location: "201", Dates: []EventDate{date}}}
+	ignoreTeacher := WithComparator(func(a, b Event) bool {
+		a.Teacher, b.Teacher = "", ""
+		return ComputeID(a) == ComputeID(b)
+	})
+
+	if got := Deduplicate(a); len(got) != 2 {
+		t.Errorf("len(Deduplicate()) = %d, want %d", len(got), 2)
+	}
+	if got, want := Deduplicate(a, ignoreTeacher), a[:1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("Deduplicate(WithComparator()) = %v, want %v", got, want)
+	}
+	if got, want := MergeSchedules(a[:1], b, ignoreTeacher), b; !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeSchedules(WithComparator()) = %v, want %v", got, want)
+	}
+}
+
+func TestGroupByFrequency(t *testing.T) {
+	once := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 9, 5, 10, 10, 0, 0, loc), Frequency: FrequencyOnce}
+	every := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyEvery}
+	throughout := EventDate{Start: time.Date(2000, 9, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 12, 10, 10, 0, 0, loc), Frequency: FrequencyThroughout}
+	even := EventDate{Start: time.Date(2000, 9, 12, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 12, 10, 10, 0, 0, loc), Frequency: FrequencyThroughout, Parity: ParityEven}
+	odd := EventDate{Start: time.Date(2000, 9, 5, 8, 30, 0, 0, loc), End: time.Date(2000, 12, 5, 10, 10, 0, 0, loc), Frequency: FrequencyThroughout, Parity: ParityOdd}
+	continuous := EventDate{Start: time.Date(2001, 7, 2, 0, 0, 0, 0, loc), End: time.Date(2001, 7, 15, 23, 59, 0, 0, loc), Frequency: FrequencyContinuous}
+	events := []Event{
+		{Title: "Weekly", Dates: []EventDate{every}},
+		{Title: "Biweekly", Dates: []EventDate{throughout}},
+		{Title: "Hybrid", Dates: []EventDate{every, once}},
+		{Title: "Practice", Dates: []EventDate{continuous}},
+		{Title: "Alternating", Dates: []EventDate{even, odd}},
+		{Title: "WithoutDates"},
+	}
+
+	want := map[Frequency][]Event{
+		FrequencyEvery:          {{Title: "Weekly", Dates: []EventDate{every}}, {Title: "Hybrid", Dates: []EventDate{every}}},
+		FrequencyThroughout:     {{Title: "Biweekly", Dates: []EventDate{throughout}}},
+		FrequencyOnce:           {{Title: "Hybrid", Dates: []EventDate{once}}},
+		FrequencyContinuous:     {{Title: "Practice", Dates: []EventDate{continuous}}},
+		FrequencyThroughoutEven: {{Title: "Alternating", Dates: []EventDate{even}}},
+		FrequencyThroughoutOdd:  {{Title: "Alternating", Dates: []EventDate{odd}}},
+	}
+	if got := GroupByFrequency(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupByFrequency() = %v, want %v", got, want)
+	}
+}
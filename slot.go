@@ -0,0 +1,49 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"sort"
+	"time"
+)
+
+// Interval contains start and end datetime of time span.
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// FreeSlots returns gaps between events occurring on civil date of given date
+// within [dayStart, dayEnd]. Overlapping events are merged before gaps are computed.
+func FreeSlots(events []Event, date time.Time, dayStart, dayEnd time.Time) []Interval {
+	busy := make([]Interval, 0)
+	for i := range events {
+		for j := range events[i].Dates {
+			if interval, ok := events[i].Dates[j].occursOn(date); ok {
+				busy = append(busy, interval)
+			}
+		}
+	}
+	sort.Slice(busy, func(i, j int) bool { return busy[i].Start.Before(busy[j].Start) })
+
+	slots := make([]Interval, 0)
+	start := dayStart
+	for _, interval := range busy {
+		if interval.Start.After(start) {
+			end := interval.Start
+			if end.After(dayEnd) {
+				end = dayEnd
+			}
+			if end.After(start) {
+				slots = append(slots, Interval{start, end})
+			}
+		}
+		if interval.End.After(start) {
+			start = interval.End
+		}
+	}
+	if dayEnd.After(start) {
+		slots = append(slots, Interval{start, dayEnd})
+	}
+	return slots
+}
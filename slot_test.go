@@ -0,0 +1,51 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFreeSlots(t *testing.T) {
+	at := func(hour, min int) time.Time { return time.Date(2000, 9, 5, hour, min, 0, 0, loc) }
+	weekly := func(start, end time.Time) Event {
+		return Event{Dates: []EventDate{{Start: start.AddDate(0, 0, -7), End: end.AddDate(0, 0, 14), Frequency: FrequencyEvery}}}
+	}
+
+	tests := []struct {
+		name   string
+		events []Event
+		want   []Interval
+	}{
+		{
+			"BackToBack",
+			[]Event{weekly(at(8, 30), at(10, 10)), weekly(at(10, 10), at(12, 0)), weekly(at(9, 0), at(11, 0))},
+			[]Interval{{at(12, 0), at(18, 0)}},
+		},
+		{
+			"MiddayBreak",
+			[]Event{
+				weekly(at(8, 30), at(10, 10)),
+				{Dates: []EventDate{{Start: at(14, 10), End: at(15, 50), Frequency: FrequencyOnce}}},
+			},
+			[]Interval{{at(10, 10), at(14, 10)}, {at(15, 50), at(18, 0)}},
+		},
+		{
+			"OtherDay",
+			[]Event{
+				{Dates: []EventDate{{Start: at(14, 10).AddDate(0, 0, 1), End: at(15, 50).AddDate(0, 0, 1), Frequency: FrequencyOnce}}},
+				{Dates: []EventDate{{Start: at(10, 20).AddDate(0, 0, -7), End: at(12, 0).AddDate(0, 0, 7), Frequency: FrequencyThroughout}}},
+			},
+			[]Interval{{at(8, 30), at(18, 0)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FreeSlots(tt.events, at(0, 0), at(8, 30), at(18, 0)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FreeSlots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"io"
+	"time"
+
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+// StreamRawEvents reads pdf content from r page by page and passes raw events to fn as soon as they are formed,
+// so that texts of only one page are held in memory. Pages are those of WithPages, or all pages of pdf file.
+// Cell beginning on one page and ending on the next one forms single raw event.
+// Weekdays, groups, footnotes and highlighting require whole content, so they aren't detected.
+// Streaming stops at the first error returned by fn.
+func (p *Parser) StreamRawEvents(r io.ReaderAt, size int64, initialDate time.Time, fn func(raw RawEvent) error) error {
+	s := &rawEventScanner{p: p, initialDate: p.initialDate(initialDate)}
+	yield := func(rawEvents []RawEvent) error {
+		p.repair(rawEvents)
+		for _, raw := range rawEvents {
+			if err := fn(raw); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	err := reader.ReadPages(r, size, reader.Options{Pages: p.pages}, func(page *reader.Content) error {
+		if err := s.scan(p.orient(page).Texts); err != nil {
+			return err
+		}
+		return yield(s.take())
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.close(); err != nil {
+		return err
+	}
+	return yield(s.rawEvents)
+}
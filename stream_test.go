@@ -0,0 +1,83 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/pdftest"
+)
+
+func TestParser_StreamRawEvents(t *testing.T) {
+	content := pdftest.Build(
+		[]pdf.Text{
+			{X: 46, Y: 500, S: "First. Teacher T.T. лекции. Location. [05.09]"},
+			{X: 46, Y: 480, S: "Second. Teacher T.T. лекции."},
+		},
+		[]pdf.Text{
+			{X: 46, Y: 500, S: "Location. [12.09]"},
+			{X: 46, Y: 480, S: "Third. Teacher T.T. лекции. [19.09]"},
+		},
+		[]pdf.Text{
+			{X: 46, Y: 500, S: "(Note)"},
+		},
+	)
+
+	var got []string
+	err := NewParser().StreamRawEvents(bytes.NewReader(content), int64(len(content)), time.Time{}, func(raw RawEvent) error {
+		got = append(got, raw.Data())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Parser.StreamRawEvents() error = %v", err)
+	}
+	want := []string{
+		"First. Teacher T.T. лекции. Location. [05.09]",
+		"Second. Teacher T.T. лекции. Location. [12.09]",
+		"Third. Teacher T.T. лекции. [19.09] (Note)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parser.StreamRawEvents() = %q, want %q", got, want)
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = NewParser().StreamRawEvents(bytes.NewReader(content), int64(len(content)), time.Time{}, func(raw RawEvent) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("Parser.StreamRawEvents() error = %v after %d calls, want %v after 1 call", err, calls, errStop)
+	}
+}
+
+func Test_rawEventScanner_take(t *testing.T) {
+	runes := func(texts ...pdf.Text) []pdf.Text {
+		split := make([]pdf.Text, 0)
+		for _, text := range texts {
+			for _, r := range text.S {
+				split = append(split, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+			}
+		}
+		return split
+	}
+
+	s := &rawEventScanner{p: NewParser()}
+	if err := s.scan(runes(pdf.Text{X: 46, Y: 500, S: "First. лекции. [05.09]"}, pdf.Text{X: 46, Y: 480, S: "Second. лекции. [12.09]"})); err != nil {
+		t.Fatalf("rawEventScanner.scan() error = %v", err)
+	}
+	if got := s.take(); len(got) != 1 || got[0].data != "First. лекции. [05.09]" {
+		t.Errorf("rawEventScanner.take() = %v, want only the first raw event", got)
+	}
+	if err := s.scan(runes(pdf.Text{X: 46, Y: 500, S: "Third. лекции."})); err != nil {
+		t.Fatalf("rawEventScanner.scan() error = %v", err)
+	}
+	if got := s.take(); len(got) != 1 || got[0].data != "Second. лекции. [12.09]" {
+		t.Errorf("rawEventScanner.take() = %v, want the second raw event", got)
+	}
+}
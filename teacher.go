@@ -0,0 +1,29 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// teacherRegexp matches teacher name with one or two initials at the end, e.g. "Иванов И. И".
+var teacherRegexp = regexp.MustCompile(`^(.+?)\s+(\p{Lu})\.?\s*(?:(\p{Lu})\.?)?$`)
+
+// NormalizeTeacher returns teacher name in canonical form "Иванов И.И.":
+// whitespace is collapsed, initials are written without spaces and each is followed by period.
+// Several teachers separated by commas are normalized separately.
+func NormalizeTeacher(teacher string) string {
+	names := strings.Split(teacher, ",")
+	for i, name := range names {
+		name = strings.Join(strings.Fields(name), " ")
+		if submatches := teacherRegexp.FindStringSubmatch(name); submatches != nil {
+			name = submatches[1] + " " + submatches[2] + "."
+			if submatches[3] != "" {
+				name += submatches[3] + "."
+			}
+		}
+		names[i] = name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,29 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import "testing"
+
+func TestNormalizeTeacher(t *testing.T) {
+	tests := []struct {
+		name    string
+		teacher string
+		want    string
+	}{
+		{"Canonical", "Иванов И.И.", "Иванов И.И."},
+		{"SpacedInitials", "Иванов И. И.", "Иванов И.И."},
+		{"MissingPeriod", "Иванов И.И", "Иванов И.И."},
+		{"ExtraWhitespace", " Иванов  И.  И. ", "Иванов И.И."},
+		{"SingleInitial", "Иванов И", "Иванов И."},
+		{"WithoutInitials", "Иванов", "Иванов"},
+		{"SeveralTeachers", "Иванов И. И.,Петров П.П", "Иванов И.И., Петров П.П."},
+		{"Empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTeacher(tt.teacher); got != tt.want {
+				t.Errorf("NormalizeTeacher() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
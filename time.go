@@ -2,7 +2,13 @@
 
 package scheduleparser
 
-import "errors"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Clock contains hours and minutes values.
 type Clock struct {
@@ -17,6 +23,37 @@ type EventTime struct {
 	end   Clock
 }
 
+// TimeSlot is start and end of time slot of events table measured from midnight.
+type TimeSlot struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// newClock returns Clock of duration d since midnight.
+func newClock(d time.Duration) Clock {
+	return Clock{int(d / time.Hour), int(d % time.Hour / time.Minute)}
+}
+
+// newEventTimes converts time slots to slice of EventTime.
+func newEventTimes(slots []TimeSlot) []EventTime {
+	times := make([]EventTime, len(slots))
+	for i, slot := range slots {
+		times[i] = EventTime{newClock(slot.Start), newClock(slot.End)}
+	}
+	return times
+}
+
+// pairNumber returns 1-based number of slot of times starting at clock time of t,
+// or 0 if there is no such slot.
+func pairNumber(t time.Time, times []EventTime) int {
+	for i := range times {
+		if times[i].start.hour == t.Hour() && times[i].start.min == t.Minute() {
+			return i + 1
+		}
+	}
+	return 0
+}
+
 // eventTimes is slice of determined EventTime instances.
 var eventTimes = [...]EventTime{
 	{Clock{8, 30}, Clock{10, 10}},
@@ -29,22 +66,144 @@ var eventTimes = [...]EventTime{
 	{Clock{21, 20}, Clock{22, 50}},
 }
 
-// parseTime gets *EventTime by raw event position,
-// and returns it.
+// leadingTimeRegexp matches time range preceding title of event, e.g. "10:15-11:45" or "8-9:30".
+// Time range is written as start and end separated by dash, and minutes of start or end may be omitted:
+// "8-9:30" is 08:00-09:30, "8:00-9:30" is 08:00-09:30 and "8-9" is 08:00-09:00.
+// Hours are 0-23, minutes are 00-59, and end must be after start.
+var leadingTimeRegexp = regexp.MustCompile(`^\s*(\d{1,2})(?:[:.](\d{2}))?\s*[-‐‑–—−]\s*(\d{1,2})(?:[:.](\d{2}))?\.?\s+`)
+
+// newTimeRange returns *EventTime of hour and minute submatches of time range,
+// where omitted minutes are empty, or error if time range is incorrect.
+func newTimeRange(submatches []string) (*EventTime, error) {
+	values := make([]int, 4)
+	for i, submatch := range submatches {
+		if submatch != "" {
+			values[i], _ = strconv.Atoi(submatch)
+		}
+	}
+	if values[0] > 23 || values[1] > 59 || values[2] > 23 || values[3] > 59 {
+		return nil, fmt.Errorf("%w: time is out of range", ErrDateParse)
+	}
+	start, end := Clock{values[0], values[1]}, Clock{values[2], values[3]}
+	if end.hour*60+end.min <= start.hour*60+start.min {
+		return nil, fmt.Errorf("%w: end of time range isn't after start", ErrDateParse)
+	}
+	return &EventTime{start, end}, nil
+}
+
+// clockPattern matches time with optional minutes and AM/PM marker, e.g. "13:45", "1:45 PM" or "1 p.m.".
+const clockPattern = `\d{1,2}(?::\d{2})?(?:\s*[AaPp]\.?\s*[Mm]\.?)?`
+
+var (
+	// layoutLeadingTimeRegexp is leadingTimeRegexp of time range parsed by layouts.
+	// Time range of Parser with WithTimeLayouts is written as start and end times separated by dash,
+	// and each time is parsed by layouts, e.g. "1:45 PM-3 PM" by "3:04 PM" and "3 PM".
+	layoutLeadingTimeRegexp = regexp.MustCompile(`^\s*(` + clockPattern + `)\s*[-‐‑–—−]\s*(` + clockPattern + `)\.?\s+`)
+	// layoutDateTimeRegexp is dateTimeRegexp of time range parsed by layouts.
+	layoutDateTimeRegexp = regexp.MustCompile(`\s+(` + clockPattern + `)\s*-\s*(` + clockPattern + `)$`)
+	// meridiemRegexp matches AM/PM marker at the end of time with preceding spaces.
+	meridiemRegexp = regexp.MustCompile(`\s*([AaPp])\.?\s*[Mm]\.?$`)
+)
+
+// defaultTimeLayouts are layouts of 24-hour times with optional minutes used by WithTimeLayouts without layouts.
+var defaultTimeLayouts = []string{"15:04", "15"}
+
+// parseClock parses time by the first of layouts it matches and returns Clock of it,
+// or error listing layouts if time matches none of them.
+// AM/PM marker is normalized to uppercase preceded by space, e.g. "1:45pm" becomes "1:45 PM".
+func parseClock(value string, layouts []string) (Clock, error) {
+	if submatches := meridiemRegexp.FindStringSubmatch(value); submatches != nil {
+		value = strings.TrimSuffix(value, submatches[0]) + " " + strings.ToUpper(submatches[1]) + "M"
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Clock{t.Hour(), t.Minute()}, nil
+		}
+	}
+	return Clock{}, fmt.Errorf("%w: time %q doesn't match layouts %q", ErrDateParse, value, layouts)
+}
+
+// newLayoutTimeRange returns *EventTime of start and end times parsed by layouts,
+// or error if any of them doesn't match layouts or end isn't after start.
+func newLayoutTimeRange(start, end string, layouts []string) (*EventTime, error) {
+	startClock, err := parseClock(start, layouts)
+	if err != nil {
+		return nil, err
+	}
+	endClock, err := parseClock(end, layouts)
+	if err != nil {
+		return nil, err
+	}
+	if endClock.hour*60+endClock.min <= startClock.hour*60+startClock.min {
+		return nil, fmt.Errorf("%w: end of time range isn't after start", ErrDateParse)
+	}
+	return &EventTime{startClock, endClock}, nil
+}
+
+// parseLeadingTime searches for time range at the start of data,
+// returns *EventTime of it and data without it, or false if there is no correct range.
+// Times are parsed by layouts unless layouts are nil.
+func parseLeadingTime(data string, layouts []string) (*EventTime, string, bool) {
+	if layouts != nil {
+		submatches := layoutLeadingTimeRegexp.FindStringSubmatch(data)
+		if submatches == nil {
+			return nil, data, false
+		}
+		eventTime, err := newLayoutTimeRange(submatches[1], submatches[2], layouts)
+		if err != nil {
+			return nil, data, false
+		}
+		return eventTime, data[len(submatches[0]):], true
+	}
+	submatches := leadingTimeRegexp.FindStringSubmatch(data)
+	if submatches == nil {
+		return nil, data, false
+	}
+	eventTime, err := newTimeRange(submatches[1:])
+	if err != nil {
+		return nil, data, false
+	}
+	return eventTime, data[len(submatches[0]):], true
+}
+
+// parseTime gets *EventTime by time labels of raw event row or else by raw event position,
+// and returns it. Time written in raw event data takes precedence over both of them.
+// Non-zero shift extends end of time to end of slot shift positions later, e.g. lab lasting two slots has shift 1.
+// Shift doesn't refer to tokens of data, so subgroup of lab is parsed from data as of any other type.
 func parseTime(raw *RawEvent, shift int) (*EventTime, error) {
+	if raw.eventTime != nil {
+		return raw.eventTime, nil
+	}
+	if raw.labelTimes != nil {
+		if shift >= len(raw.labelTimes) {
+			return nil, fmt.Errorf("%w: shift is out of range", ErrMalformedCell)
+		}
+		start, end := raw.labelTimes[0].start, raw.labelTimes[shift].end
+		if end.hour*60+end.min <= start.hour*60+start.min {
+			return nil, fmt.Errorf("%w: shift is out of range", ErrMalformedCell)
+		}
+		return &EventTime{start, end}, nil
+	}
+	times := eventTimes[:]
+	if raw.settings().times != nil {
+		times = raw.settings().times
+	}
 	var timesIndex int
 
 	pos := map[int]int{46: 0, 139: 1, 233: 2, 327: 3, 420: 4, 514: 5, 607: 6}
-	timesIndex, ok := pos[int(raw.position.X)]
+	timesIndex, ok := pos[int(raw.position.X-raw.offset)]
 	if !ok {
 		timesIndex = 7
 	}
+	if timesIndex >= len(times) {
+		return nil, fmt.Errorf("%w: time slot %d is not configured", ErrMalformedCell, timesIndex+1)
+	}
 
 	if shift != 0 {
-		if timesIndex+shift >= len(eventTimes) {
-			return nil, errors.New("shift is out of range")
+		if timesIndex+shift >= len(times) {
+			return nil, fmt.Errorf("%w: shift is out of range", ErrMalformedCell)
 		}
-		return &EventTime{eventTimes[timesIndex].start, eventTimes[timesIndex+shift].end}, nil
+		return &EventTime{times[timesIndex].start, times[timesIndex+shift].end}, nil
 	}
-	return &eventTimes[timesIndex], nil
+	return &times[timesIndex], nil
 }
@@ -3,11 +3,14 @@
 package scheduleparser
 
 import (
+	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
 )
 
 func Test_parseTime(t *testing.T) {
@@ -24,7 +27,7 @@ func Test_parseTime(t *testing.T) {
 		{
 			"ZeroWithoutShift",
 			args{
-				&RawEvent{"", pdf.Point{X: 46, Y: 0}, time.Time{}},
+				&RawEvent{data: "", position: pdf.Point{X: 46, Y: 0}},
 				0,
 			},
 			&eventTimes[0],
@@ -33,7 +36,7 @@ func Test_parseTime(t *testing.T) {
 		{
 			"ZeroWithShift",
 			args{
-				&RawEvent{"", pdf.Point{X: 46, Y: 0}, time.Time{}},
+				&RawEvent{data: "", position: pdf.Point{X: 46, Y: 0}},
 				1,
 			},
 			&EventTime{Clock{8, 30}, Clock{12, 0}},
@@ -42,7 +45,7 @@ func Test_parseTime(t *testing.T) {
 		{
 			"SeventhWithoutShift",
 			args{
-				&RawEvent{"", pdf.Point{X: 700, Y: 0}, time.Time{}},
+				&RawEvent{data: "", position: pdf.Point{X: 700, Y: 0}},
 				0,
 			},
 			&eventTimes[7],
@@ -51,7 +54,7 @@ func Test_parseTime(t *testing.T) {
 		{
 			"ShiftError",
 			args{
-				&RawEvent{"", pdf.Point{X: 46, Y: 0}, time.Time{}},
+				&RawEvent{data: "", position: pdf.Point{X: 46, Y: 0}},
 				8,
 			},
 			nil,
@@ -71,3 +74,95 @@ func Test_parseTime(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseLeadingTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     *EventTime
+		wantData string
+		wantOk   bool
+	}{
+		{"Colon", "10:15-11:45 Title. лекции. 101. [05.09]", &EventTime{Clock{10, 15}, Clock{11, 45}}, "Title. лекции. 101. [05.09]", true},
+		{"DotAndDash", "9.00 – 10.30. Title. лекции. 101. [05.09]", &EventTime{Clock{9, 0}, Clock{10, 30}}, "Title. лекции. 101. [05.09]", true},
+		{"Absent", "Title. лекции. 101. [05.09]", nil, "Title. лекции. 101. [05.09]", false},
+		{"OutOfRange", "25:00-26:00 Title. лекции. 101. [05.09]", nil, "25:00-26:00 Title. лекции. 101. [05.09]", false},
+		{"StartHourOnly", "8-9:30 Title. лекции. 101. [05.09]", &EventTime{Clock{8, 0}, Clock{9, 30}}, "Title. лекции. 101. [05.09]", true},
+		{"FullStart", "8:00-9:30 Title. лекции. 101. [05.09]", &EventTime{Clock{8, 0}, Clock{9, 30}}, "Title. лекции. 101. [05.09]", true},
+		{"HoursOnly", "8-9 Title. лекции. 101. [05.09]", &EventTime{Clock{8, 0}, Clock{9, 0}}, "Title. лекции. 101. [05.09]", true},
+		{"EndBeforeStart", "9-8 Title. лекции. 101. [05.09]", nil, "9-8 Title. лекции. 101. [05.09]", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotData, gotOk := parseLeadingTime(tt.data, nil)
+			if !reflect.DeepEqual(got, tt.want) || gotData != tt.wantData || gotOk != tt.wantOk {
+				t.Errorf("parseLeadingTime() = %v, %q, %v, want %v, %q, %v", got, gotData, gotOk, tt.want, tt.wantData, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_pairNumber(t *testing.T) {
+	times := newEventTimes([]TimeSlot{
+		{8*time.Hour + 30*time.Minute, 10*time.Hour + 10*time.Minute},
+		{10*time.Hour + 20*time.Minute, 12 * time.Hour},
+		{12*time.Hour + 20*time.Minute, 14 * time.Hour},
+	})
+
+	tests := []struct {
+		name  string
+		start time.Time
+		want  int
+	}{
+		{"First", time.Date(2000, 9, 5, 8, 30, 0, 0, loc), 1},
+		{"Third", time.Date(2000, 9, 5, 12, 20, 0, 0, loc), 3},
+		{"InsideSlot", time.Date(2000, 9, 5, 9, 0, 0, 0, loc), 0},
+		{"AfterSlots", time.Date(2000, 9, 5, 18, 0, 0, 0, loc), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pairNumber(tt.start, times); got != tt.want {
+				t.Errorf("pairNumber() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithTimeLayouts(t *testing.T) {
+	initialDate := time.Date(2000, 8, 20, 0, 0, 0, 0, loc)
+	content := func(data string) *reader.Content {
+		texts := make([]pdf.Text, 0)
+		for _, r := range data {
+			texts = append(texts, pdf.Text{X: 46, Y: 500, S: string(r)})
+		}
+		return &reader.Content{Texts: texts}
+	}
+	tests := []struct {
+		name      string
+		opts      []Option
+		data      string
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{"Default", []Option{WithTimeLayouts()}, "Title. лекции. 101. [12.09 13:45-15:15]", time.Date(2000, 9, 12, 13, 45, 0, 0, loc), time.Date(2000, 9, 12, 15, 15, 0, 0, loc)},
+		{"DefaultHoursOnly", []Option{WithTimeLayouts()}, "Title. лекции. 101. [12.09 8-9:30]", time.Date(2000, 9, 12, 8, 0, 0, 0, loc), time.Date(2000, 9, 12, 9, 30, 0, 0, loc)},
+		{"AMPM", []Option{WithTimeLayouts("3:04 PM", "3 PM")}, "Title. лекции. 101. [12.09 11:30 AM-1 p.m.]", time.Date(2000, 9, 12, 11, 30, 0, 0, loc), time.Date(2000, 9, 12, 13, 0, 0, 0, loc)},
+		{"LeadingAMPM", []Option{WithTimeLayouts("3:04 PM")}, "1:45pm-3:15pm Title. лекции. 101. [12.09]", time.Date(2000, 9, 12, 13, 45, 0, 0, loc), time.Date(2000, 9, 12, 15, 15, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewParser(tt.opts...).parseText(content(tt.data), initialDate, "")
+			if err != nil {
+				t.Fatalf("Parser.parseText() error = %v", err)
+			}
+			if date := schedule.Events[0].Dates[0]; !date.Start.Equal(tt.wantStart) || !date.End.Equal(tt.wantEnd) {
+				t.Errorf("date = %v-%v, want %v-%v", date.Start, date.End, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+
+	_, err := NewParser(WithTimeLayouts("3:04 PM", "3 PM")).parseText(content("Title. лекции. 101. [12.09 13:45-15:15]"), initialDate, "")
+	if !errors.Is(err, ErrDateParse) || !strings.Contains(err.Error(), `["3:04 PM" "3 PM"]`) {
+		t.Errorf("Parser.parseText() error = %v, want %v listing layouts", err, ErrDateParse)
+	}
+}
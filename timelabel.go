@@ -0,0 +1,80 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"math"
+	"regexp"
+	"sort"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// timeLabelDistance is maximum vertical distance between time label and row of raw event it applies to.
+const timeLabelDistance = 20
+
+// timeLabelRegexp matches time range of row label, e.g. "10:15-11:45" or "2 пара 10.20 – 12.00".
+var timeLabelRegexp = regexp.MustCompile(`(?:^|\s)(\d{1,2})(?:[:.](\d{2}))?\s*[-‐‑–—−]\s*(\d{1,2})(?:[:.](\d{2}))?(?:\s|$)`)
+
+// timeLabel is time range found in row labels column.
+type timeLabel struct {
+	y         float64
+	eventTime EventTime
+}
+
+// getTimeLabels takes slice of pdf.Text and returns time labels
+// placed to the left of events cells. Consecutive texts with the same Y coordinate form a label,
+// so label can also contain weekday, e.g. "Понедельник 10:15-11:45".
+func getTimeLabels(texts []pdf.Text) []timeLabel {
+	labels := make([]timeLabel, 0)
+	var (
+		label string
+		y     float64
+	)
+	flush := func() {
+		if submatches := timeLabelRegexp.FindStringSubmatch(label); submatches != nil {
+			if eventTime, err := newTimeRange(submatches[1:]); err == nil {
+				labels = append(labels, timeLabel{y, *eventTime})
+			}
+		}
+		label = ""
+	}
+	for _, text := range texts {
+		if text.Y >= tableTop || text.X > tableLeft {
+			continue
+		}
+		if label != "" && text.Y != y {
+			flush()
+		}
+		label += text.S
+		y = text.Y
+	}
+	flush()
+	return labels
+}
+
+// setTimeLabels sets time of each raw event to time of label nearest to its row
+// within timeLabelDistance. Times of labels below it are kept as well,
+// so that event lasting several slots, e.g. lab, ends at end of time of label of its last row.
+// Time written in raw event data still takes precedence over label.
+// Raw events stay with time of their position if there is no such label.
+func setTimeLabels(rawEvents []RawEvent, labels []timeLabel) {
+	labels = append([]timeLabel(nil), labels...)
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i].y > labels[j].y })
+	for i := range rawEvents {
+		nearest := -1
+		for j := range labels {
+			distance := math.Abs(labels[j].y - rawEvents[i].position.Y)
+			if distance <= timeLabelDistance && (nearest < 0 || distance < math.Abs(labels[nearest].y-rawEvents[i].position.Y)) {
+				nearest = j
+			}
+		}
+		if nearest >= 0 {
+			times := make([]EventTime, 0, len(labels)-nearest)
+			for _, label := range labels[nearest:] {
+				times = append(times, label.eventTime)
+			}
+			rawEvents[i].labelTimes = times
+		}
+	}
+}
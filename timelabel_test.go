@@ -0,0 +1,79 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/qsoulior/scheduleparser/internal/reader"
+)
+
+func Test_setTimeLabels(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 5, Y: 500, S: "10:15-11:45"},
+		{X: 46, Y: 500, S: "История. лекции. 101. [05.09]"},
+		{X: 233, Y: 500, S: "Физика. семинар. 202. [05.09]"},
+		{X: 420, Y: 500, S: "12:00-13:30 Химия. семинар. 203. [05.09]"},
+		{X: 46, Y: 400, S: "Право. лекции. 102. [05.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+
+	schedule, err := NewParser().parseText(&reader.Content{Texts: texts}, time.Date(2000, 8, 20, 0, 0, 0, 0, loc), "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	want := map[string]string{
+		"История": "10:15-11:45",
+		"Физика":  "10:15-11:45",
+		"Химия":   "12:00-13:30",
+		"Право":   "08:30-10:10",
+	}
+	got := make(map[string]string)
+	for _, event := range schedule.Events {
+		date := event.Dates[0]
+		got[event.Title] = date.Start.Format("15:04") + "-" + date.End.Format("15:04")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("times = %v, want %v", got, want)
+	}
+}
+
+func Test_setTimeLabels_lab(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 5, Y: 500, S: "10:15-11:45"},
+		{X: 46, Y: 500, S: "Химия. лабораторные занятия. 203. [05.09]"},
+		{X: 5, Y: 450, S: "12:00-13:30"},
+		{X: 5, Y: 400, S: "13:45-15:15"},
+		{X: 46, Y: 400, S: "Физика. лабораторные занятия. 202. [05.09]"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+
+	var errs []error
+	schedule, err := NewParser(WithErrorHandler(func(_ int, _ RawEvent, err error) { errs = append(errs, err) })).
+		parseText(&reader.Content{Texts: texts}, time.Date(2000, 8, 20, 0, 0, 0, 0, loc), "")
+	if err != nil {
+		t.Fatalf("Parser.parseText() error = %v", err)
+	}
+	if len(schedule.Events) != 1 || schedule.Events[0].Title != "Химия" {
+		t.Fatalf("Parser.parseText() events = %v, want single event %q", schedule.Events, "Химия")
+	}
+	date := schedule.Events[0].Dates[0]
+	if got, want := date.Start.Format("15:04")+"-"+date.End.Format("15:04"), "10:15-13:30"; got != want {
+		t.Errorf("time = %s, want %s", got, want)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrMalformedCell) {
+		t.Errorf("errors = %v, want single %v for lab in the last row", errs, ErrMalformedCell)
+	}
+}
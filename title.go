@@ -0,0 +1,18 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import "regexp"
+
+// courseCodeRegexp matches course code in parentheses at the end of title, e.g. " (Б1.О.12)".
+var courseCodeRegexp = regexp.MustCompile(`\s*\(([\pL\d]+(?:\.[\pL\d]+)+)\)$`)
+
+// splitCourseCode returns title without trailing course code and the code,
+// or title and empty string if title has no such code.
+func splitCourseCode(title string) (string, string) {
+	submatches := courseCodeRegexp.FindStringSubmatchIndex(title)
+	if submatches == nil || submatches[0] == 0 {
+		return title, ""
+	}
+	return title[:submatches[0]], title[submatches[2]:submatches[3]]
+}
@@ -0,0 +1,29 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import "testing"
+
+func Test_splitCourseCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		title     string
+		wantTitle string
+		wantCode  string
+	}{
+		{"Code", "Физика (Б1.О.12)", "Физика", "Б1.О.12"},
+		{"LatinCode", "Physics (B1.V.DV.01)", "Physics", "B1.V.DV.01"},
+		{"WithoutCode", "Физика", "Физика", ""},
+		{"ParenthesesNotCode", "Физика (часть 1)", "Физика (часть 1)", ""},
+		{"CodeNotAtEnd", "Физика (Б1.О.12) часть 1", "Физика (Б1.О.12) часть 1", ""},
+		{"CodeOnly", "(Б1.О.12)", "(Б1.О.12)", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotTitle, gotCode := splitCourseCode(tt.title)
+			if gotTitle != tt.wantTitle || gotCode != tt.wantCode {
+				t.Errorf("splitCourseCode() = %q, %q, want %q, %q", gotTitle, gotCode, tt.wantTitle, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// weekdayNames maps Russian weekday names to weekdays.
+var weekdayNames = map[string]time.Weekday{
+	"понедельник": time.Monday,
+	"вторник":     time.Tuesday,
+	"среда":       time.Wednesday,
+	"четверг":     time.Thursday,
+	"пятница":     time.Friday,
+	"суббота":     time.Saturday,
+	"воскресенье": time.Sunday,
+}
+
+// parseWeekday returns weekday by its English name, e.g. "Monday", and false if name is unknown.
+func parseWeekday(name string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if weekday.String() == name {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// weekdayLabel is weekday name found in row labels column.
+type weekdayLabel struct {
+	y       float64
+	weekday time.Weekday
+}
+
+// getWeekdayLabels takes slice of pdf.Text and returns weekday labels
+// placed to the left of events cells. Consecutive texts with the same Y coordinate form a label.
+func getWeekdayLabels(texts []pdf.Text) []weekdayLabel {
+	labels := make([]weekdayLabel, 0)
+	var (
+		label string
+		y     float64
+	)
+	flush := func() {
+		if weekday, ok := weekdayNames[strings.ToLower(strings.TrimSpace(label))]; ok {
+			labels = append(labels, weekdayLabel{y, weekday})
+		}
+		label = ""
+	}
+	for _, text := range texts {
+		if text.Y >= tableTop || text.X > tableLeft {
+			continue
+		}
+		if label != "" && text.Y != y {
+			flush()
+		}
+		label += text.S
+		y = text.Y
+	}
+	flush()
+	return labels
+}
+
+// setWeekdays sets weekday of each raw event to weekday of label nearest to its row at or above it,
+// since label of day is placed at the first row of day block and lower rows of the block belong to it too.
+// Raw events above all labels get weekday of the topmost label.
+// Raw events stay without weekday if there are no labels.
+func setWeekdays(rawEvents []RawEvent, labels []weekdayLabel) {
+	if len(labels) == 0 {
+		return
+	}
+	const tolerance = 2
+	for i := range rawEvents {
+		y := rawEvents[i].position.Y
+		var nearest *weekdayLabel
+		for j := range labels {
+			label := &labels[j]
+			if label.y+tolerance < y {
+				continue
+			}
+			if nearest == nil || label.y < nearest.y {
+				nearest = label
+			}
+		}
+		if nearest == nil {
+			nearest = &labels[0]
+			for j := range labels {
+				if labels[j].y > nearest.y {
+					nearest = &labels[j]
+				}
+			}
+		}
+		rawEvents[i].weekday = nearest.weekday.String()
+	}
+}
@@ -0,0 +1,64 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ledongthuc/pdf"
+)
+
+func Test_setWeekdays(t *testing.T) {
+	texts := make([]pdf.Text, 0)
+	for _, text := range []pdf.Text{
+		{X: 300, Y: 570, S: "Понедельник"},
+		{X: 5, Y: 500, S: "Понедельник"},
+		{X: 5, Y: 430, S: "Вторник"},
+		{X: 5, Y: 360, S: "СРЕДА"},
+		{X: 5, Y: 290, S: "Четверг"},
+		{X: 5, Y: 220, S: "пятница"},
+		{X: 5, Y: 150, S: "Суббота"},
+		{X: 5, Y: 80, S: "Воскресенье"},
+		{X: 5, Y: 40, S: "Примечание"},
+	} {
+		for _, r := range text.S {
+			texts = append(texts, pdf.Text{X: text.X, Y: text.Y, S: string(r)})
+		}
+	}
+
+	labels := getWeekdayLabels(texts)
+	if len(labels) != 7 {
+		t.Fatalf("len(getWeekdayLabels()) = %d, want %d", len(labels), 7)
+	}
+
+	rawEvents := []RawEvent{
+		{position: pdf.Point{X: 46, Y: 501}},
+		{position: pdf.Point{X: 139, Y: 430}},
+		{position: pdf.Point{X: 233, Y: 355}},
+		{position: pdf.Point{X: 46, Y: 290}},
+		{position: pdf.Point{X: 46, Y: 215}},
+		{position: pdf.Point{X: 46, Y: 150}},
+		{position: pdf.Point{X: 46, Y: 70}},
+		// Lower rows of day blocks are nearer to labels of the next days, and the last row is above all labels.
+		{position: pdf.Point{X: 46, Y: 460}},
+		{position: pdf.Point{X: 139, Y: 390}},
+		{position: pdf.Point{X: 46, Y: 550}},
+	}
+	setWeekdays(rawEvents, labels)
+
+	want := []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday", "Monday", "Tuesday", "Monday"}
+	got := make([]string, len(rawEvents))
+	for i, raw := range rawEvents {
+		got[i] = raw.weekday
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("weekdays = %v, want %v", got, want)
+	}
+
+	withoutLabels := []RawEvent{{position: pdf.Point{X: 46, Y: 510}}}
+	setWeekdays(withoutLabels, getWeekdayLabels(nil))
+	if withoutLabels[0].weekday != "" {
+		t.Errorf("weekday = %q, want empty", withoutLabels[0].weekday)
+	}
+}
@@ -0,0 +1,51 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import "strings"
+
+// yoWords are common words of schedules written with ё.
+var yoWords = []string{
+	"зачёт", "зачёта", "зачёты", "зачётов", "зачётом", "зачётная", "зачётное",
+	"отчёт", "отчёта", "отчёты", "отчётов",
+	"учёт", "учёта", "расчёт", "расчёта", "расчётов",
+	"ещё", "объём", "подъём",
+}
+
+// newYoDictionary returns map of words with ё replaced by е to words themselves
+// for built-in and given words.
+func newYoDictionary(words []string) map[string]string {
+	dictionary := make(map[string]string, len(yoWords)+len(words))
+	for _, list := range [][]string{yoWords, words} {
+		for _, word := range list {
+			word = strings.ToLower(word)
+			dictionary[strings.ReplaceAll(word, "ё", "е")] = word
+		}
+	}
+	return dictionary
+}
+
+// restoreYo replaces words of s found in dictionary, keeping case of their letters.
+func restoreYo(s string, dictionary map[string]string) string {
+	return wordRegexp.ReplaceAllStringFunc(s, func(word string) string {
+		restored, ok := dictionary[strings.ToLower(word)]
+		if !ok {
+			return word
+		}
+		runes, restoredRunes := []rune(word), []rune(restored)
+		for i, r := range runes {
+			if r == 'е' && restoredRunes[i] == 'ё' {
+				runes[i] = 'ё'
+			} else if r == 'Е' && restoredRunes[i] == 'ё' {
+				runes[i] = 'Ё'
+			}
+		}
+		return string(runes)
+	})
+}
+
+// RestoreYo returns s with ё restored in common words written with е, e.g. "зачет" becomes "зачёт".
+// Words are taken from small built-in dictionary and given words written with ё.
+func RestoreYo(s string, words ...string) string {
+	return restoreYo(s, newYoDictionary(words))
+}
@@ -0,0 +1,28 @@
+// Package scheduleparser implements structs and functions to parse events from pdf content.
+
+package scheduleparser
+
+import "testing"
+
+func TestRestoreYo(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		words []string
+		want  string
+	}{
+		{"Word", "зачет", nil, "зачёт"},
+		{"Capitalized", "Зачет по дисциплине", nil, "Зачёт по дисциплине"},
+		{"Uppercase", "ЗАЧЕТ", nil, "ЗАЧЁТ"},
+		{"Unknown", "Семенов С.С.", nil, "Семенов С.С."},
+		{"Addition", "Семенов С.С., зачет", []string{"Семёнов"}, "Семёнов С.С., зачёт"},
+		{"PartOfWord", "зачетка", nil, "зачетка"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RestoreYo(tt.s, tt.words...); got != tt.want {
+				t.Errorf("RestoreYo() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}